@@ -0,0 +1,125 @@
+package sequence
+
+import "context"
+import "errors"
+
+//ChanIterator provides an Iterable over the values received from a channel,
+//keyed by the order they arrived in
+type ChanIterator struct {
+	recv  func() (interface{}, bool)
+	clone func() *ChanIterator
+	value interface{}
+	index int
+	done  bool
+}
+
+//FromChan returns an iterator receiving its values from the channel, it ends
+//with ErrENDINDEX once the channel is closed. A channel can not be replayed,
+//so Reset only restarts the keys and clones share the channel with the source
+func FromChan[T any](ch <-chan T) *ChanIterator {
+	return &ChanIterator{
+		recv: func() (interface{}, bool) {
+			v, ok := <-ch
+			return v, ok
+		},
+		clone: func() *ChanIterator {
+			return FromChan(ch)
+		},
+		index: -1,
+	}
+}
+
+//Next blocks until the next value is received
+func (c *ChanIterator) Next() error {
+	if c.done {
+		return ErrENDINDEX
+	}
+
+	v, ok := c.recv()
+
+	if !ok {
+		c.done = true
+		return ErrENDINDEX
+	}
+
+	c.value = v
+	c.index++
+	return nil
+}
+
+//Reset restarts the keys of the iterator
+func (c *ChanIterator) Reset() {
+	c.value = nil
+	c.index = -1
+}
+
+//Key returns the current index of the iterator
+func (c *ChanIterator) Key() interface{} {
+	if c.index < 0 {
+		return nil
+	}
+	return c.index
+}
+
+//Value returns the value of the data with the index value
+func (c *ChanIterator) Value() interface{} {
+	return c.value
+}
+
+//Length returns UNKNOWNLENGTH as a channel has no size
+func (c *ChanIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off the same channel
+func (c *ChanIterator) Clone() Iterable {
+	return c.clone()
+}
+
+//ToChan sends the values of a clone of the iterable on a channel with the
+//given buffer size, 0 giving an unbuffered channel for backpressure and a
+//negative size counting as 0. Any error other than ErrENDINDEX is sent on the
+//error channel, both channels are closed once the iterable is done
+func ToChan(it Iterable, buff int) (<-chan interface{}, <-chan error) {
+	return ToChanContext(context.Background(), it, buff)
+}
+
+//ToChanContext is ToChan that stops sending and reports ctx.Err() once the
+//context is done, letting the goroutine go when the reader walks away
+func ToChanContext(ctx context.Context, it Iterable, buff int) (<-chan interface{}, <-chan error) {
+	if buff < 0 {
+		buff = 0
+	}
+
+	values := make(chan interface{}, buff)
+	errs := make(chan error, 1)
+	c := WithContext(ctx, it)
+
+	go func() {
+		defer close(errs)
+		defer close(values)
+		defer c.Stop()
+
+		for {
+			err := c.Next()
+
+			if errors.Is(err, ErrENDINDEX) {
+				return
+			}
+
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			select {
+			case values <- c.Value():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return values, errs
+}
@@ -0,0 +1,97 @@
+package sequence
+
+import "context"
+import "testing"
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int)
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+
+	it := FromChan(ch)
+
+	if res := collect(it); !sameValues(res, []interface{}{0, 1, 2, 3, 4}) {
+		t.Fatal("values from the channel are incorrect", res)
+	}
+
+	if it.Key() != 4 {
+		t.Fatal("keys should count the received values", it.Key())
+	}
+
+	if it.Next() != ErrENDINDEX {
+		t.Fatal("closed channel should end with ErrENDINDEX")
+	}
+}
+
+func TestToChan(t *testing.T) {
+	values, errs := ToChan(NewListIterator(data), 2)
+
+	if cap(values) != 2 {
+		t.Fatal("channel should use the given buffer", cap(values))
+	}
+
+	var res []interface{}
+	for v := range values {
+		res = append(res, v)
+	}
+
+	if err := <-errs; err != nil {
+		t.Fatal("clean end should not send an error", err)
+	}
+
+	if !sameValues(res, data) {
+		t.Fatal("values from the iterator are incorrect", res)
+	}
+
+	bad := NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Length() >= 2 {
+			return nil, nil, ErrBADValue
+		}
+		return p.Length(), p.Length(), nil
+	})
+
+	values, errs = ToChan(bad, 0)
+
+	if cap(values) != 0 {
+		t.Fatal("a zero buffer should give an unbuffered channel", cap(values))
+	}
+
+	n := 0
+	for range values {
+		n++
+	}
+
+	if err := <-errs; err != ErrBADValue || n != 2 {
+		t.Fatal("failures should be sent on the error channel", n, err)
+	}
+
+	if values, _ = ToChan(NewListIterator(nil), -3); cap(values) != 0 {
+		t.Fatal("a negative buffer should count as 0", cap(values))
+	}
+}
+
+func TestToChanContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	values, errs := ToChanContext(ctx, counter(), 0)
+
+	<-values
+	<-values
+	cancel()
+
+	for range values {
+	}
+
+	if err := <-errs; err != context.Canceled {
+		t.Fatal("cancelled pipe should report context.Canceled", err)
+	}
+
+	it := FromChan(values)
+	if it.Next() != ErrENDINDEX {
+		t.Fatal("closed pipe should end the channel iterator")
+	}
+}
@@ -0,0 +1,174 @@
+package sequence
+
+import "reflect"
+
+//GetE retrieves the value at the index, it returns ErrKeyType if the key is
+//not an int and ErrOutOfRange if it is not in the list
+func (l *ListSequence) GetE(d interface{}) (interface{}, error) {
+	i, ok := d.(int)
+
+	if !ok {
+		return nil, ErrKeyType
+	}
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if i < 0 || i >= len(l.data) {
+		return nil, ErrOutOfRange
+	}
+
+	return l.data[i], nil
+}
+
+//AddE appends the items, it never fails and is there to match
+//MapSequence.AddE
+func (l *ListSequence) AddE(f ...interface{}) error {
+	l.Add(f...)
+	return nil
+}
+
+//DeleteE removes the items at each index in turn as Delete does, but checks
+//every index first. It removes nothing and returns ErrKeyType if one is not
+//an int or ErrOutOfRange if one is not in the list at its turn
+func (l *ListSequence) DeleteE(f ...interface{}) error {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	size := len(l.data)
+
+	for _, v := range f {
+		i, ok := v.(int)
+
+		if !ok {
+			return ErrKeyType
+		}
+
+		if i < 0 || i >= size {
+			return ErrOutOfRange
+		}
+
+		size--
+	}
+
+	l.del(f...)
+	return nil
+}
+
+//MutateE allows mutation on a copy of the sequence data, it returns
+//ErrResultType and leaves the data as it was if fn does not return a list
+func (l *ListSequence) MutateE(fn MutFunc) error {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	old := l.data
+	l.shared.Store(true)
+	l.own()
+
+	res, ok := fn(l.data).([]interface{})
+
+	if !ok {
+		l.data = old
+		l.shared.Store(true)
+		return ErrResultType
+	}
+
+	l.data = res
+	l.mutated(old)
+	return nil
+}
+
+//keyable reports if the key can be used in a map without panicking, values
+//held in interface fields are checked too
+func keyable(k interface{}) bool {
+	return k == nil || reflect.ValueOf(k).Comparable()
+}
+
+//GetE retrieves the value of the key, it returns ErrKeyType if the key can
+//not be a map key and ErrNotFound if it is not in the map
+func (l *MapSequence) GetE(d interface{}) (interface{}, error) {
+	if !keyable(d) {
+		return nil, ErrKeyType
+	}
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	v, ok := l.data[d]
+
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return v, nil
+}
+
+//AddE sets each key and value pair. It sets nothing and returns ErrOddPairs,
+//an ErrBADValue, if a key has no value or ErrKeyType if a key can not be a
+//map key
+func (l *MapSequence) AddE(f ...interface{}) error {
+	if len(f)%2 != 0 {
+		return ErrOddPairs
+	}
+
+	for i := 0; i < len(f); i += 2 {
+		if !keyable(f[i]) {
+			return ErrKeyType
+		}
+	}
+
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+	l.add(f...)
+	return nil
+}
+
+//DeleteE removes the keys. It removes nothing and returns ErrKeyType if a key
+//can not be a map key or ErrNotFound if one is not in the map
+func (l *MapSequence) DeleteE(f ...interface{}) error {
+	for _, k := range f {
+		if !keyable(k) {
+			return ErrKeyType
+		}
+	}
+
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	gone := make(map[interface{}]bool, len(f))
+
+	for _, k := range f {
+		if _, ok := l.data[k]; !ok || gone[k] {
+			return ErrNotFound
+		}
+
+		gone[k] = true
+	}
+
+	l.del(f...)
+	return nil
+}
+
+//MutateE allows mutation on a copy of the sequence data, it returns
+//ErrResultType and leaves the data as it was if fn does not return a map
+func (l *MapSequence) MutateE(fn MutFunc) error {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	old := l.data
+	l.shared.Store(true)
+	l.own()
+
+	res, ok := fn(l.data).(map[interface{}]interface{})
+
+	if !ok {
+		l.data = old
+		l.shared.Store(true)
+		return ErrResultType
+	}
+
+	l.data = res
+	l.syncKeys()
+	l.mutated(old)
+	return nil
+}
@@ -0,0 +1,146 @@
+package sequence
+
+import "errors"
+import "testing"
+import "time"
+
+//released fails the test if the sequence lock is still held
+func released(t *testing.T, s *Sequence) {
+	done := make(chan struct{})
+
+	go func() {
+		s.lock.Lock()
+		s.lock.Unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sequence lock was not released")
+	}
+}
+
+func TestListSequenceChecked(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2, 3}, 0)
+
+	if _, err := ls.GetE("a"); err != ErrKeyType {
+		t.Fatal("get with a bad key should return ErrKeyType", err)
+	}
+
+	if _, err := ls.GetE(3); err != ErrOutOfRange {
+		t.Fatal("get past the end should return ErrOutOfRange", err)
+	}
+
+	if v, err := ls.GetE(1); err != nil || v != 2 {
+		t.Fatal("get is incorrect", v, err)
+	}
+
+	if ls.Get(-1) != nil || ls.Get("a") != nil {
+		t.Fatal("unchecked get should return nil on bad keys")
+	}
+
+	if err := ls.DeleteE(0, "a"); err != ErrKeyType || ls.Length() != 3 {
+		t.Fatal("delete with a bad key should return ErrKeyType and remove nothing", err)
+	}
+
+	if err := ls.DeleteE(0, 2); err != ErrOutOfRange || ls.Length() != 3 {
+		t.Fatal("delete past the end at its turn should return ErrOutOfRange", err)
+	}
+
+	if err := ls.AddE(4); err != nil {
+		t.Fatal("add should not fail", err)
+	}
+
+	if err := ls.DeleteE(0, 2); err != nil || !sameValues(ls.Obj(), []interface{}{2, 3}) {
+		t.Fatal("delete is incorrect", ls.Obj(), err)
+	}
+
+	err := ls.MutateE(func(f interface{}) interface{} {
+		list := f.([]interface{})
+		list[0] = 99
+		return "bad"
+	})
+
+	if err != ErrResultType || !sameValues(ls.Obj(), []interface{}{2, 3}) {
+		t.Fatal("bad mutate should return ErrResultType and keep the data", ls.Obj(), err)
+	}
+
+	released(t, ls.Sequence)
+
+	ls.Mutate(func(f interface{}) interface{} {
+		return 1
+	})
+	ls.Delete("a", 0)
+
+	if !sameValues(ls.Obj(), []interface{}{3}) {
+		t.Fatal("unchecked delete should skip bad keys", ls.Obj())
+	}
+
+	released(t, ls.Sequence)
+}
+
+func TestMapSequenceChecked(t *testing.T) {
+	ms := NewMapSequence(map[interface{}]interface{}{"a": 1}, 0)
+
+	if _, err := ms.GetE([]int{1}); err != ErrKeyType {
+		t.Fatal("get with an unhashable key should return ErrKeyType", err)
+	}
+
+	if _, err := ms.GetE("x"); err != ErrNotFound {
+		t.Fatal("get of a missing key should return ErrNotFound", err)
+	}
+
+	if err := ms.AddE("b", 2, "c"); err != ErrOddPairs || !errors.Is(err, ErrBADValue) || ms.Length() != 1 {
+		t.Fatal("add with odd pairs should return ErrOddPairs and set nothing", err)
+	}
+
+	if err := ms.AddE("b", 2, []int{1}, 3); err != ErrKeyType || ms.Length() != 1 {
+		t.Fatal("add with an unhashable key should return ErrKeyType and set nothing", err)
+	}
+
+	type holder struct{ v interface{} }
+
+	if err := ms.AddE(holder{[]int{1}}, 3); err != ErrKeyType || ms.Length() != 1 {
+		t.Fatal("add with an unhashable value inside a key should return ErrKeyType", err)
+	}
+
+	if _, err := ms.GetE(holder{[]int{1}}); err != ErrKeyType {
+		t.Fatal("get with an unhashable value inside a key should return ErrKeyType", err)
+	}
+
+
+	if err := ms.AddE("b", 2, "c", 3); err != nil || ms.Length() != 3 {
+		t.Fatal("add is incorrect", ms.Obj(), err)
+	}
+
+	if err := ms.DeleteE("b", "x"); err != ErrNotFound || ms.Length() != 3 {
+		t.Fatal("delete of a missing key should return ErrNotFound and remove nothing", err)
+	}
+
+	if err := ms.DeleteE("b", "b"); err != ErrNotFound || ms.Length() != 3 {
+		t.Fatal("deleting a key twice should return ErrNotFound", err)
+	}
+
+	if err := ms.DeleteE(map[int]int{}); err != ErrKeyType {
+		t.Fatal("delete with an unhashable key should return ErrKeyType", err)
+	}
+
+	if err := ms.MutateE(func(f interface{}) interface{} { return nil }); err != ErrResultType || ms.Length() != 3 {
+		t.Fatal("bad mutate should return ErrResultType and keep the data", err)
+	}
+
+	released(t, ms.Sequence)
+
+	ms.Add("d")
+	ms.Delete("x", "b")
+	ms.Mutate(func(f interface{}) interface{} {
+		return 1
+	})
+
+	if ms.Length() != 2 || ms.Get("b") != nil || ms.Get("d") != nil {
+		t.Fatal("unchecked writes should skip bad input", ms.Obj())
+	}
+
+	released(t, ms.Sequence)
+}
@@ -0,0 +1,463 @@
+package sequence
+
+import "errors"
+
+//sizeOf returns the length of an iterator when it is known ahead of iteration.
+//GenerativeIterator only reports the values it has produced so far, so
+//anything built off it is of unknown length
+func sizeOf(it Iterable) (int, bool) {
+	switch t := it.(type) {
+	case *GenerativeIterator:
+		return UNKNOWNLENGTH, false
+	case *BaseIterator:
+		return sizeOf(t.parent)
+	}
+
+	n := it.Length()
+	return n, n >= 0
+}
+
+//Map returns an iterator that transforms each key and value of the iterable
+//with the supplied function
+func Map(it Iterable, fn ProcFunc) *BaseIterator {
+	return NewBaseIterator(it, fn)
+}
+
+//FilterIterator only yields the items of its parent that pass its predicate
+type FilterIterator struct {
+	parent Iterable
+	pred   PredFunc
+	value  interface{}
+	index  interface{}
+}
+
+//Filter returns an iterator that drops every item failing the predicate
+func Filter(it Iterable, fn PredFunc) *FilterIterator {
+	return &FilterIterator{
+		it.Clone(),
+		fn,
+		nil,
+		nil,
+	}
+}
+
+//Next moves to the next item that passes the predicate
+func (l *FilterIterator) Next() error {
+	for {
+		err := l.parent.Next()
+
+		if errors.Is(err, ErrBADValue) {
+			l.value = nil
+			l.index = nil
+			return err
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if l.pred(l.parent) {
+			l.value = l.parent.Value()
+			l.index = l.parent.Key()
+			return nil
+		}
+	}
+}
+
+//Reset reverst the iterators index
+func (l *FilterIterator) Reset() {
+	l.parent.Reset()
+	l.value = nil
+	l.index = nil
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *FilterIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current index of the iterator
+func (l *FilterIterator) Key() interface{} {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *FilterIterator) Value() interface{} {
+	return l.value
+}
+
+//Length returns UNKNOWNLENGTH as the filtered size is only known after a full
+//iteration
+func (l *FilterIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (l *FilterIterator) Clone() Iterable {
+	return Filter(l.parent, l.pred)
+}
+
+//TakeIterator yields at most a fixed number of items from its parent
+type TakeIterator struct {
+	parent Iterable
+	max    int
+	count  int
+}
+
+//Take returns an iterator that ends after n items of the iterable
+func Take(it Iterable, n int) *TakeIterator {
+	if n < 0 {
+		n = 0
+	}
+
+	return &TakeIterator{
+		it.Clone(),
+		n,
+		0,
+	}
+}
+
+//Next moves to the next item
+func (l *TakeIterator) Next() error {
+	if l.count >= l.max {
+		return ErrENDINDEX
+	}
+
+	if err := l.parent.Next(); err != nil {
+		return err
+	}
+
+	l.count++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (l *TakeIterator) Reset() {
+	l.parent.Reset()
+	l.count = 0
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *TakeIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current index of the iterator
+func (l *TakeIterator) Key() interface{} {
+	return l.parent.Key()
+}
+
+//Value returns the value of the data with the index value
+func (l *TakeIterator) Value() interface{} {
+	return l.parent.Value()
+}
+
+//Length returns the smaller of the limit and the parents length or
+//UNKNOWNLENGTH if the parent can not tell
+func (l *TakeIterator) Length() int {
+	n, ok := sizeOf(l.parent)
+
+	if !ok {
+		return UNKNOWNLENGTH
+	}
+
+	if n < l.max {
+		return n
+	}
+
+	return l.max
+}
+
+//Clone returns a new iterator off that data
+func (l *TakeIterator) Clone() Iterable {
+	return Take(l.parent, l.max)
+}
+
+//SkipIterator drops a fixed number of items from the start of its parent
+type SkipIterator struct {
+	parent  Iterable
+	skip    int
+	skipped bool
+}
+
+//Skip returns an iterator that starts after the first n items of the iterable
+func Skip(it Iterable, n int) *SkipIterator {
+	if n < 0 {
+		n = 0
+	}
+
+	return &SkipIterator{
+		it.Clone(),
+		n,
+		false,
+	}
+}
+
+//Next moves to the next item
+func (l *SkipIterator) Next() error {
+	if !l.skipped {
+		l.skipped = true
+
+		for i := 0; i < l.skip; i++ {
+			if err := l.parent.Next(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return l.parent.Next()
+}
+
+//Reset reverst the iterators index
+func (l *SkipIterator) Reset() {
+	l.parent.Reset()
+	l.skipped = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *SkipIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current index of the iterator
+func (l *SkipIterator) Key() interface{} {
+	return l.parent.Key()
+}
+
+//Value returns the value of the data with the index value
+func (l *SkipIterator) Value() interface{} {
+	return l.parent.Value()
+}
+
+//Length returns the parents length less the skipped items or UNKNOWNLENGTH if
+//the parent can not tell
+func (l *SkipIterator) Length() int {
+	n, ok := sizeOf(l.parent)
+
+	if !ok {
+		return UNKNOWNLENGTH
+	}
+
+	if n < l.skip {
+		return 0
+	}
+
+	return n - l.skip
+}
+
+//Clone returns a new iterator off that data
+func (l *SkipIterator) Clone() Iterable {
+	return Skip(l.parent, l.skip)
+}
+
+//TakeWhileIterator yields items from its parent until its predicate fails,
+//keeping the last item it yielded once it ends
+type TakeWhileIterator struct {
+	parent Iterable
+	pred   PredFunc
+	done   bool
+	value  interface{}
+	index  interface{}
+}
+
+//TakeWhile returns an iterator that ends at the first item failing the
+//predicate
+func TakeWhile(it Iterable, fn PredFunc) *TakeWhileIterator {
+	return &TakeWhileIterator{
+		it.Clone(),
+		fn,
+		false,
+		nil,
+		nil,
+	}
+}
+
+//Next moves to the next item
+func (l *TakeWhileIterator) Next() error {
+	if l.done {
+		return ErrENDINDEX
+	}
+
+	err := l.parent.Next()
+
+	if errors.Is(err, ErrBADValue) {
+		l.value = nil
+		l.index = nil
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !l.pred(l.parent) {
+		l.done = true
+		return ErrENDINDEX
+	}
+
+	l.value = l.parent.Value()
+	l.index = l.parent.Key()
+	return nil
+}
+
+//Reset reverst the iterators index
+func (l *TakeWhileIterator) Reset() {
+	l.parent.Reset()
+	l.done = false
+	l.value = nil
+	l.index = nil
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *TakeWhileIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current index of the iterator
+func (l *TakeWhileIterator) Key() interface{} {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *TakeWhileIterator) Value() interface{} {
+	return l.value
+}
+
+//Length returns UNKNOWNLENGTH as the cut off point is only known after
+//iteration
+func (l *TakeWhileIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (l *TakeWhileIterator) Clone() Iterable {
+	return TakeWhile(l.parent, l.pred)
+}
+
+//SkipWhileIterator drops items from its parent until its predicate fails
+type SkipWhileIterator struct {
+	parent  Iterable
+	pred    PredFunc
+	skipped bool
+}
+
+//SkipWhile returns an iterator that starts at the first item failing the
+//predicate
+func SkipWhile(it Iterable, fn PredFunc) *SkipWhileIterator {
+	return &SkipWhileIterator{
+		it.Clone(),
+		fn,
+		false,
+	}
+}
+
+//Next moves to the next item
+func (l *SkipWhileIterator) Next() error {
+	if l.skipped {
+		return l.parent.Next()
+	}
+
+	for {
+		if err := l.parent.Next(); err != nil {
+			return err
+		}
+
+		if !l.pred(l.parent) {
+			l.skipped = true
+			return nil
+		}
+	}
+}
+
+//Reset reverst the iterators index
+func (l *SkipWhileIterator) Reset() {
+	l.parent.Reset()
+	l.skipped = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *SkipWhileIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current index of the iterator
+func (l *SkipWhileIterator) Key() interface{} {
+	return l.parent.Key()
+}
+
+//Value returns the value of the data with the index value
+func (l *SkipWhileIterator) Value() interface{} {
+	return l.parent.Value()
+}
+
+//Length returns UNKNOWNLENGTH as the starting point is only known after
+//iteration
+func (l *SkipWhileIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (l *SkipWhileIterator) Clone() Iterable {
+	return SkipWhile(l.parent, l.pred)
+}
+
+//EnumerateIterator replaces the keys of its parent with a running count
+type EnumerateIterator struct {
+	parent Iterable
+	index  int
+}
+
+//Enumerate returns an iterator whoes keys are the position of each item
+//starting from 0, while the values are those of the iterable
+func Enumerate(it Iterable) *EnumerateIterator {
+	return &EnumerateIterator{
+		it.Clone(),
+		-1,
+	}
+}
+
+//Next moves to the next item
+func (l *EnumerateIterator) Next() error {
+	if err := l.parent.Next(); err != nil {
+		return err
+	}
+
+	l.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (l *EnumerateIterator) Reset() {
+	l.parent.Reset()
+	l.index = -1
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *EnumerateIterator) Stop() {
+	stop(l.parent)
+}
+
+//Key returns the current position of the iterator
+func (l *EnumerateIterator) Key() interface{} {
+	if l.index < 0 {
+		return nil
+	}
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *EnumerateIterator) Value() interface{} {
+	return l.parent.Value()
+}
+
+//Length returns the parent iterators length or UNKNOWNLENGTH if the parent can
+//not tell
+func (l *EnumerateIterator) Length() int {
+	n, _ := sizeOf(l.parent)
+	return n
+}
+
+//Clone returns a new iterator off that data
+func (l *EnumerateIterator) Clone() Iterable {
+	return Enumerate(l.parent)
+}
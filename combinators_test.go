@@ -0,0 +1,194 @@
+package sequence
+
+import "testing"
+
+func collect(it Iterable) []interface{} {
+	var res []interface{}
+
+	for it.Next() == nil {
+		res = append(res, it.Value())
+	}
+
+	return res
+}
+
+func sameValues(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func counter() *GenerativeIterator {
+	return NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Value() == nil {
+			return 0, 0, nil
+		}
+
+		cur, _ := p.Value().(int)
+		return cur + 1, cur + 1, nil
+	})
+}
+
+func even(f Iterable) bool {
+	v, _ := f.Value().(int)
+	return v%2 == 0
+}
+
+func TestMapCombinator(t *testing.T) {
+	it := Map(NewListIterator(data), func(f Iterable) (interface{}, interface{}, error) {
+		v, _ := f.Value().(int)
+		return v * 2, f.Key(), nil
+	})
+
+	res := collect(it)
+
+	if !sameValues(res, []interface{}{2, 64, 112, 14}) {
+		t.Fatal("mapped values are incorrect", res)
+	}
+
+	if it.Length() != len(data) {
+		t.Fatal("mapped length should equal its source", it.Length())
+	}
+}
+
+func TestFilterCombinator(t *testing.T) {
+	it := Filter(NewListIterator(data), even)
+
+	res := collect(it)
+
+	if !sameValues(res, []interface{}{32, 56}) {
+		t.Fatal("filtered values are incorrect", res)
+	}
+
+	if it.Length() != UNKNOWNLENGTH {
+		t.Fatal("filtered length can not be known ahead", it.Length())
+	}
+
+	it.Reset()
+
+	if err := it.Next(); err != nil || it.Key() != 1 {
+		t.Fatal("reset filter should restart from the source", err, it.Key())
+	}
+
+	if cl := collect(it.Clone()); !sameValues(cl, res) {
+		t.Fatal("cloned filter should yield the same values", cl)
+	}
+}
+
+func TestTakeAndSkip(t *testing.T) {
+	tk := Take(NewListIterator(data), 2)
+
+	if tk.Length() != 2 {
+		t.Fatal("take length should be 2", tk.Length())
+	}
+
+	if res := collect(tk); !sameValues(res, []interface{}{1, 32}) {
+		t.Fatal("take values are incorrect", res)
+	}
+
+	if tk.Next() != ErrENDINDEX {
+		t.Fatal("take should end with ErrENDINDEX")
+	}
+
+	if tk.Key() != 1 || tk.Value() != 32 {
+		t.Fatal("ended take should keep its last item", tk.Key(), tk.Value())
+	}
+
+	sk := Skip(NewListIterator(data), 3)
+
+	if sk.Length() != 1 {
+		t.Fatal("skip length should be 1", sk.Length())
+	}
+
+	if res := collect(sk); !sameValues(res, []interface{}{7}) {
+		t.Fatal("skip values are incorrect", res)
+	}
+
+	sk.Reset()
+
+	if res := collect(sk); !sameValues(res, []interface{}{7}) {
+		t.Fatal("reset skip values are incorrect", res)
+	}
+
+	if Skip(NewListIterator(data), 10).Length() != 0 {
+		t.Fatal("skipping past the end should have no length")
+	}
+
+	gen := Take(counter(), 5)
+
+	if gen.Length() != UNKNOWNLENGTH {
+		t.Fatal("take over a generator can not know its length", gen.Length())
+	}
+
+	if res := collect(gen); !sameValues(res, []interface{}{0, 1, 2, 3, 4}) {
+		t.Fatal("take should bound a generator", res)
+	}
+}
+
+func TestTakeWhileAndSkipWhile(t *testing.T) {
+	small := func(f Iterable) bool {
+		v, _ := f.Value().(int)
+		return v < 50
+	}
+
+	tw := TakeWhile(NewListIterator(data), small)
+
+	if res := collect(tw); !sameValues(res, []interface{}{1, 32}) {
+		t.Fatal("takewhile values are incorrect", res)
+	}
+
+	if tw.Key() != 1 || tw.Value() != 32 {
+		t.Fatal("ended takewhile should keep its last item, not the failing one", tw.Key(), tw.Value())
+	}
+
+	sw := SkipWhile(NewListIterator(data), small)
+
+	if res := collect(sw); !sameValues(res, []interface{}{56, 7}) {
+		t.Fatal("skipwhile values are incorrect", res)
+	}
+
+	if res := collect(sw.Clone()); !sameValues(res, []interface{}{56, 7}) {
+		t.Fatal("cloned skipwhile values are incorrect", res)
+	}
+
+	lt := TakeWhile(counter(), func(f Iterable) bool {
+		v, _ := f.Value().(int)
+		return v < 3
+	})
+
+	if res := collect(lt); !sameValues(res, []interface{}{0, 1, 2}) {
+		t.Fatal("takewhile should bound a generator", res)
+	}
+}
+
+func TestEnumerate(t *testing.T) {
+	en := Enumerate(Filter(NewListIterator(data), even))
+
+	if en.Key() != nil {
+		t.Fatal("enumerate should have no key before Next", en.Key())
+	}
+
+	pos := 0
+	for en.Next() == nil {
+		if en.Key() != pos {
+			t.Fatal("enumerate key is not the position", en.Key(), pos)
+		}
+		pos++
+	}
+
+	if pos != 2 {
+		t.Fatal("enumerate yielded the wrong number of items", pos)
+	}
+
+	if Enumerate(NewListIterator(data)).Length() != len(data) {
+		t.Fatal("enumerate length should equal its source")
+	}
+}
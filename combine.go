@@ -0,0 +1,388 @@
+package sequence
+
+import "errors"
+
+//Pair holds the values of two iterators at the same position
+type Pair struct {
+	Left  interface{}
+	Right interface{}
+}
+
+//ZipIterator walks two iterators side by side, yielding their values as a Pair
+//keyed by position
+type ZipIterator struct {
+	left    Iterable
+	right   Iterable
+	longest bool
+	pad     interface{}
+	lend    bool
+	rend    bool
+	value   interface{}
+	index   int
+}
+
+//Zip returns an iterator pairing the values of a and b, it ends with the
+//shorter of the two
+func Zip(a, b Iterable) *ZipIterator {
+	return &ZipIterator{
+		left:  a.Clone(),
+		right: b.Clone(),
+		index: -1,
+	}
+}
+
+//ZipLongest returns an iterator pairing the values of a and b until both have
+//ended, the side that ends first is padded with the pad value
+func ZipLongest(a, b Iterable, pad interface{}) *ZipIterator {
+	z := Zip(a, b)
+	z.longest = true
+	z.pad = pad
+	return z
+}
+
+//step moves one side of the zip, reporting its value or the pad once it ended
+func (z *ZipIterator) step(it Iterable, ended *bool) (interface{}, error) {
+	if *ended {
+		return z.pad, nil
+	}
+
+	err := it.Next()
+
+	if errors.Is(err, ErrENDINDEX) {
+		*ended = true
+		return z.pad, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return it.Value(), nil
+}
+
+//Next moves both iterators to their next item. Unless padding, the right
+//one is left untouched once the left one has ended
+func (z *ZipIterator) Next() error {
+	if !z.longest && (z.lend || z.rend) {
+		return ErrENDINDEX
+	}
+
+	l, err := z.step(z.left, &z.lend)
+
+	if err != nil {
+		return err
+	}
+
+	if !z.longest && z.lend {
+		z.value = nil
+		return ErrENDINDEX
+	}
+
+	r, err := z.step(z.right, &z.rend)
+
+	if err != nil {
+		return err
+	}
+
+	if z.lend && z.rend || !z.longest && (z.lend || z.rend) {
+		z.value = nil
+		return ErrENDINDEX
+	}
+
+	z.value = Pair{l, r}
+	z.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (z *ZipIterator) Reset() {
+	z.left.Reset()
+	z.right.Reset()
+	z.lend = false
+	z.rend = false
+	z.value = nil
+	z.index = -1
+}
+
+//Stop releases the parent iterators if they hold resources
+func (z *ZipIterator) Stop() {
+	stop(z.left)
+	stop(z.right)
+}
+
+//Key returns the current position of the iterator
+func (z *ZipIterator) Key() interface{} {
+	if z.index < 0 {
+		return nil
+	}
+	return z.index
+}
+
+//Value returns the current Pair of the iterator
+func (z *ZipIterator) Value() interface{} {
+	return z.value
+}
+
+//Length returns the shorter length of both iterators, or the longer one when
+//padding, and UNKNOWNLENGTH if either can not tell
+func (z *ZipIterator) Length() int {
+	l, lok := sizeOf(z.left)
+	r, rok := sizeOf(z.right)
+
+	if !lok || !rok {
+		return UNKNOWNLENGTH
+	}
+
+	if z.longest == (l < r) {
+		return r
+	}
+
+	return l
+}
+
+//Clone returns a new iterator off that data
+func (z *ZipIterator) Clone() Iterable {
+	c := Zip(z.left, z.right)
+	c.longest = z.longest
+	c.pad = z.pad
+	return c
+}
+
+//ChainIterator walks a list of iterators one after the other
+type ChainIterator struct {
+	its []Iterable
+	cur int
+}
+
+//Chain returns an iterator yielding every item of each iterable in turn,
+//keeping the keys of the iterable each item came from
+func Chain(its ...Iterable) *ChainIterator {
+	return &ChainIterator{cloneAll(its), 0}
+}
+
+func cloneAll(its []Iterable) []Iterable {
+	cl := make([]Iterable, len(its))
+
+	for i, it := range its {
+		cl[i] = it.Clone()
+	}
+
+	return cl
+}
+
+//sumLengths adds up the lengths of the iterators if every one is known
+func sumLengths(its []Iterable) int {
+	total := 0
+
+	for _, it := range its {
+		n, ok := sizeOf(it)
+
+		if !ok {
+			return UNKNOWNLENGTH
+		}
+
+		total += n
+	}
+
+	return total
+}
+
+//Next moves to the next item, moving over to the next iterable once the
+//current one ends
+func (c *ChainIterator) Next() error {
+	for c.cur < len(c.its) {
+		err := c.its[c.cur].Next()
+
+		if !errors.Is(err, ErrENDINDEX) {
+			return err
+		}
+
+		c.cur++
+	}
+
+	return ErrENDINDEX
+}
+
+//Reset reverst the iterators index
+func (c *ChainIterator) Reset() {
+	for _, it := range c.its {
+		it.Reset()
+	}
+	c.cur = 0
+}
+
+//Stop releases the parent iterators if they hold resources
+func (c *ChainIterator) Stop() {
+	for _, it := range c.its {
+		stop(it)
+	}
+}
+
+//Key returns the key of the current item in its own iterable
+func (c *ChainIterator) Key() interface{} {
+	if c.cur >= len(c.its) {
+		return nil
+	}
+	return c.its[c.cur].Key()
+}
+
+//Value returns the value of the current item
+func (c *ChainIterator) Value() interface{} {
+	if c.cur >= len(c.its) {
+		return nil
+	}
+	return c.its[c.cur].Value()
+}
+
+//Length returns the total length of the chained iterators or UNKNOWNLENGTH if
+//any of them can not tell
+func (c *ChainIterator) Length() int {
+	return sumLengths(c.its)
+}
+
+//Clone returns a new iterator off that data
+func (c *ChainIterator) Clone() Iterable {
+	return Chain(c.its...)
+}
+
+//InterleaveIterator takes one item from each of its iterators in turn
+type InterleaveIterator struct {
+	its   []Iterable
+	live  []Iterable
+	cur   int
+	item  Iterable
+	index int
+}
+
+//Interleave returns an iterator taking items from each iterable round-robin,
+//skipping the ones that have ended until all of them are done. The keys are
+//those of the iterable each item came from
+func Interleave(its ...Iterable) *InterleaveIterator {
+	cl := cloneAll(its)
+	return &InterleaveIterator{its: cl, live: append([]Iterable(nil), cl...)}
+}
+
+//Next moves to the next item of the next live iterable
+func (n *InterleaveIterator) Next() error {
+	for len(n.live) > 0 {
+		if n.cur >= len(n.live) {
+			n.cur = 0
+		}
+
+		it := n.live[n.cur]
+		err := it.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			n.live = append(n.live[:n.cur], n.live[n.cur+1:]...)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		n.item = it
+		n.cur++
+		return nil
+	}
+
+	n.item = nil
+	return ErrENDINDEX
+}
+
+//Reset reverst the iterators index
+func (n *InterleaveIterator) Reset() {
+	for _, it := range n.its {
+		it.Reset()
+	}
+	n.live = append(n.live[:0], n.its...)
+	n.cur = 0
+	n.item = nil
+}
+
+//Stop releases the parent iterators if they hold resources
+func (n *InterleaveIterator) Stop() {
+	for _, it := range n.its {
+		stop(it)
+	}
+}
+
+//Key returns the key of the current item in its own iterable
+func (n *InterleaveIterator) Key() interface{} {
+	if n.item == nil {
+		return nil
+	}
+	return n.item.Key()
+}
+
+//Value returns the value of the current item
+func (n *InterleaveIterator) Value() interface{} {
+	if n.item == nil {
+		return nil
+	}
+	return n.item.Value()
+}
+
+//Length returns the total length of the interleaved iterators or
+//UNKNOWNLENGTH if any of them can not tell
+func (n *InterleaveIterator) Length() int {
+	return sumLengths(n.its)
+}
+
+//Clone returns a new iterator off that data
+func (n *InterleaveIterator) Clone() Iterable {
+	return Interleave(n.its...)
+}
+
+//CycleIterator repeats its parent forever
+type CycleIterator struct {
+	parent Iterable
+}
+
+//Cycle returns an iterator that resets the iterable each time it ends, it
+//only ends if the iterable is empty
+func Cycle(it Iterable) *CycleIterator {
+	return &CycleIterator{it.Clone()}
+}
+
+//Next moves to the next item, starting the parent over once it ends
+func (c *CycleIterator) Next() error {
+	err := c.parent.Next()
+
+	if !errors.Is(err, ErrENDINDEX) {
+		return err
+	}
+
+	c.parent.Reset()
+	return c.parent.Next()
+}
+
+//Reset reverst the iterators index
+func (c *CycleIterator) Reset() {
+	c.parent.Reset()
+}
+
+//Stop releases the parent iterator if it holds resources
+func (c *CycleIterator) Stop() {
+	stop(c.parent)
+}
+
+//Key returns the current index of the iterator
+func (c *CycleIterator) Key() interface{} {
+	return c.parent.Key()
+}
+
+//Value returns the value of the data with the index value
+func (c *CycleIterator) Value() interface{} {
+	return c.parent.Value()
+}
+
+//Length returns UNKNOWNLENGTH as a cycle never ends
+func (c *CycleIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (c *CycleIterator) Clone() Iterable {
+	return Cycle(c.parent)
+}
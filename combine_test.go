@@ -0,0 +1,113 @@
+package sequence
+
+import "testing"
+
+func TestZip(t *testing.T) {
+	a := NewListSequence([]interface{}{1, 2, 3}, 0)
+	b := NewListSequence([]interface{}{"a", "b"}, 0)
+
+	z := Zip(a.Iterator(), b.Iterator())
+
+	if z.Length() != 2 {
+		t.Fatal("zip length should be the shorter length", z.Length())
+	}
+
+	res := collect(z)
+
+	if !sameValues(res, []interface{}{Pair{1, "a"}, Pair{2, "b"}}) {
+		t.Fatal("zipped pairs are incorrect", res)
+	}
+
+	if z.Key() != 1 {
+		t.Fatal("zip keys should be positions", z.Key())
+	}
+
+	zl := ZipLongest(a.Iterator(), b.Iterator(), "-")
+
+	if zl.Length() != 3 {
+		t.Fatal("padded zip length should be the longer length", zl.Length())
+	}
+
+	res = collect(zl)
+
+	if !sameValues(res, []interface{}{Pair{1, "a"}, Pair{2, "b"}, Pair{3, "-"}}) {
+		t.Fatal("padded pairs are incorrect", res)
+	}
+
+	if res = collect(zl.Clone()); len(res) != 3 {
+		t.Fatal("cloned padded zip should pad too", res)
+	}
+
+	if res = collect(Zip(counter(), b.Iterator())); len(res) != 2 {
+		t.Fatal("zip should end with its shorter side even against a generator", res)
+	}
+
+	ch := make(chan int, 5)
+	for i := 1; i <= 5; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	shared := Zip(b.Iterator(), FromChan(ch))
+	collect(shared)
+	shared.Next()
+
+	if res = collect(FromChan(ch)); !sameValues(res, []interface{}{3, 4, 5}) {
+		t.Fatal("zip should not take from the right side once the left ended", res)
+	}
+}
+
+func TestChain(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2}, 0)
+	ms := NewMapSequence(map[interface{}]interface{}{"k": "v"}, 0)
+
+	c := Chain(ls.Iterator(), ms.Keys().Iterator(), NewListIterator(nil))
+
+	if c.Length() != 3 {
+		t.Fatal("chain length should be the total length", c.Length())
+	}
+
+	if res := collect(c); !sameValues(res, []interface{}{1, 2, "k"}) {
+		t.Fatal("chained values are incorrect", res)
+	}
+
+	c.Reset()
+
+	if v, err := First(c); err != nil || v != 1 {
+		t.Fatal("reset chain should start over", v, err)
+	}
+
+	if Chain().Next() != ErrENDINDEX {
+		t.Fatal("empty chain should end at once")
+	}
+}
+
+func TestInterleave(t *testing.T) {
+	a := NewListIterator([]interface{}{1, 2, 3})
+	b := NewListIterator([]interface{}{"a"})
+	c := NewListIterator([]interface{}{"x", "y"})
+
+	n := Interleave(a, b, c)
+
+	if res := collect(n); !sameValues(res, []interface{}{1, "a", "x", 2, "y", 3}) {
+		t.Fatal("interleaved values are incorrect", res)
+	}
+
+	n.Reset()
+
+	if res := collect(n); len(res) != 6 {
+		t.Fatal("reset interleave should start over", res)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	res := collect(Take(Cycle(NewListIterator([]interface{}{1, 2})), 5))
+
+	if !sameValues(res, []interface{}{1, 2, 1, 2, 1}) {
+		t.Fatal("cycled values are incorrect", res)
+	}
+
+	if Cycle(NewListIterator(nil)).Next() != ErrENDINDEX {
+		t.Fatal("cycle of an empty iterator should end")
+	}
+}
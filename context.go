@@ -0,0 +1,154 @@
+package sequence
+
+import "context"
+
+//ContextProcFunc is the type of a ProcFunc that can see the context of the
+//pipeline it runs in
+type ContextProcFunc func(ctx context.Context, f Iterable) (interface{}, interface{}, error)
+
+//Contexter is implemented by iterators carrying a context
+type Contexter interface {
+	Context() context.Context
+}
+
+//ContextOf returns the context carried by the iterable or
+//context.Background when it has none, letting a ProcFunc given a
+//ContextIterator as its parent watch for cancellation
+func ContextOf(it Iterable) context.Context {
+	if c, ok := it.(Contexter); ok {
+		return c.Context()
+	}
+	return context.Background()
+}
+
+//ContextIterator ends its parent with the context error once the context is
+//done
+type ContextIterator struct {
+	ctx    context.Context
+	parent Iterable
+}
+
+//WithContext returns an iterator whoes Next returns ctx.Err() once the context
+//is cancelled or past its deadline
+func WithContext(ctx context.Context, it Iterable) *ContextIterator {
+	return &ContextIterator{ctx, it.Clone()}
+}
+
+//Context returns the context of the iterator
+func (c *ContextIterator) Context() context.Context {
+	return c.ctx
+}
+
+//Next moves to the next item, failing with the context error when the context
+//is done before or while the parent moves
+func (c *ContextIterator) Next() error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	err := c.parent.Next()
+
+	if cerr := c.ctx.Err(); cerr != nil {
+		return cerr
+	}
+
+	return err
+}
+
+//Stop releases the parent iterator if it holds resources
+func (c *ContextIterator) Stop() {
+	stop(c.parent)
+}
+
+//Reset reverst the iterators index
+func (c *ContextIterator) Reset() {
+	c.parent.Reset()
+}
+
+//Key returns the current index of the iterator
+func (c *ContextIterator) Key() interface{} {
+	return c.parent.Key()
+}
+
+//Value returns the value of the data with the index value
+func (c *ContextIterator) Value() interface{} {
+	return c.parent.Value()
+}
+
+//Length returns the parent iterators length or UNKNOWNLENGTH if the parent can
+//not tell
+func (c *ContextIterator) Length() int {
+	n, _ := sizeOf(c.parent)
+	return n
+}
+
+//Clone returns a new iterator off that data bound to the same context
+func (c *ContextIterator) Clone() Iterable {
+	return WithContext(c.ctx, c.parent)
+}
+
+//MapContext returns a cancellable Map whoes function is handed the context
+func MapContext(ctx context.Context, it Iterable, fn ContextProcFunc) *BaseIterator {
+	return NewBaseIterator(WithContext(ctx, it), func(f Iterable) (interface{}, interface{}, error) {
+		return fn(ctx, f)
+	})
+}
+
+//NewContextGenerativeIterator returns a cancellable GenerativeIterator whoes
+//function is handed the context
+func NewContextGenerativeIterator(ctx context.Context, fn ContextProcFunc) *ContextIterator {
+	return WithContext(ctx, NewGenerativeIterator(func(f Iterable) (interface{}, interface{}, error) {
+		return fn(ctx, f)
+	}))
+}
+
+//bind wraps the iterable with the context without cloning it, so the context
+//reducers consume the iterable they are given just like their plain versions
+func bind(ctx context.Context, it Iterable) Iterable {
+	return &ContextIterator{ctx, it}
+}
+
+//FoldContext is Fold stopping with ctx.Err() once the context is done
+func FoldContext(ctx context.Context, it Iterable, seed interface{}, fn ReduceFunc) (interface{}, error) {
+	return Fold(bind(ctx, it), seed, fn)
+}
+
+//ReduceContext is Reduce stopping with ctx.Err() once the context is done
+func ReduceContext(ctx context.Context, it Iterable, fn ReduceFunc) (interface{}, error) {
+	return Reduce(bind(ctx, it), fn)
+}
+
+//CountContext is Count stopping with ctx.Err() once the context is done
+func CountContext(ctx context.Context, it Iterable) (int, error) {
+	return Count(bind(ctx, it))
+}
+
+//AnyContext is Any stopping with ctx.Err() once the context is done
+func AnyContext(ctx context.Context, it Iterable, fn PredFunc) (bool, error) {
+	return Any(bind(ctx, it), fn)
+}
+
+//AllContext is All stopping with ctx.Err() once the context is done
+func AllContext(ctx context.Context, it Iterable, fn PredFunc) (bool, error) {
+	return All(bind(ctx, it), fn)
+}
+
+//FirstContext is First stopping with ctx.Err() once the context is done
+func FirstContext(ctx context.Context, it Iterable) (interface{}, error) {
+	return First(bind(ctx, it))
+}
+
+//LastContext is Last stopping with ctx.Err() once the context is done
+func LastContext(ctx context.Context, it Iterable) (interface{}, error) {
+	return Last(bind(ctx, it))
+}
+
+//MinContext is Min stopping with ctx.Err() once the context is done
+func MinContext(ctx context.Context, it Iterable, less LessFunc) (interface{}, error) {
+	return Min(bind(ctx, it), less)
+}
+
+//MaxContext is Max stopping with ctx.Err() once the context is done
+func MaxContext(ctx context.Context, it Iterable, less LessFunc) (interface{}, error) {
+	return Max(bind(ctx, it), less)
+}
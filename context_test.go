@@ -0,0 +1,82 @@
+package sequence
+
+import "context"
+import "errors"
+import "testing"
+import "time"
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	it := WithContext(ctx, counter())
+
+	for i := 0; i < 3; i++ {
+		if err := it.Next(); err != nil {
+			t.Fatal("context iterator should run before cancel", err)
+		}
+	}
+
+	cancel()
+
+	if err := it.Next(); err != context.Canceled {
+		t.Fatal("context iterator should return ctx.Err() after cancel", err)
+	}
+
+	if ContextOf(it) != ctx || ContextOf(counter()) != context.Background() {
+		t.Fatal("ContextOf should expose the iterator context")
+	}
+
+	if WithContext(ctx, NewListIterator(data)).Length() != len(data) {
+		t.Fatal("context iterator should keep its parent length")
+	}
+}
+
+func TestMapContextStopsSlowProc(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	it := MapContext(ctx, counter(), func(ctx context.Context, f Iterable) (interface{}, interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+			return f.Value(), f.Key(), nil
+		}
+	})
+
+	n, err := Count(it)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatal("count should stop with the deadline error", err)
+	}
+
+	if n == 0 {
+		t.Fatal("some items should be seen before the deadline")
+	}
+}
+
+func TestContextReducers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	seen := 0
+	gen := NewContextGenerativeIterator(ctx, func(ctx context.Context, f Iterable) (interface{}, interface{}, error) {
+		seen++
+		if seen == 5 {
+			cancel()
+		}
+		return seen, seen, nil
+	})
+
+	if _, err := LastContext(ctx, gen); err != context.Canceled {
+		t.Fatal("last over a cancelled generator should fail with context.Canceled", err)
+	}
+
+	total, err := FoldContext(context.Background(), NewListIterator(data), 0, sum)
+
+	if err != nil || total != 96 {
+		t.Fatal("fold with a live context is incorrect", total, err)
+	}
+
+	if v, err := MaxContext(ctx, NewListIterator(data), intLess); err != context.Canceled || v != nil {
+		t.Fatal("max with a done context should fail at once", v, err)
+	}
+}
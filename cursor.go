@@ -0,0 +1,73 @@
+package sequence
+
+import "errors"
+
+//Cursor walks an Iterable in the style of bufio.Scanner, Next reports if
+//there is a current item and Err tells a clean end apart from a failure
+//
+//	c := NewCursor(it)
+//	for c.Next() {
+//		use(c.Key(), c.Value())
+//	}
+//	if err := c.Err(); err != nil {
+//		...
+//	}
+type Cursor struct {
+	it   Iterable
+	err  error
+	done bool
+}
+
+//NewCursor returns a Cursor over a clone of the iterable
+func NewCursor(it Iterable) *Cursor {
+	return &Cursor{it.Clone(), nil, false}
+}
+
+//Next moves to the next item, it returns false once the iterable ends or
+//fails and keeps doing so until Reset
+func (c *Cursor) Next() bool {
+	if c.done {
+		return false
+	}
+
+	err := c.it.Next()
+
+	if err == nil {
+		return true
+	}
+
+	c.done = true
+
+	if !errors.Is(err, ErrENDINDEX) {
+		c.err = err
+	}
+
+	return false
+}
+
+//Err returns the failure that stopped the cursor, nil after a clean end
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+//Key returns the current key of the iterable
+func (c *Cursor) Key() interface{} {
+	return c.it.Key()
+}
+
+//Value returns the current value of the iterable
+func (c *Cursor) Value() interface{} {
+	return c.it.Value()
+}
+
+//Reset restarts the cursor and its iterable from the start
+func (c *Cursor) Reset() {
+	c.it.Reset()
+	c.err = nil
+	c.done = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (c *Cursor) Stop() {
+	stop(c.it)
+}
@@ -0,0 +1,93 @@
+package sequence
+
+import "errors"
+import "testing"
+
+func TestCursor(t *testing.T) {
+	c := NewCursor(NewListIterator(data))
+
+	var res []interface{}
+	for c.Next() {
+		res = append(res, c.Value())
+	}
+
+	if c.Err() != nil || !sameValues(res, data) {
+		t.Fatal("cursor should walk every item and end cleanly", res, c.Err())
+	}
+
+	if c.Next() {
+		t.Fatal("ended cursor should stay ended")
+	}
+
+	c.Reset()
+
+	if !c.Next() || c.Key() != 0 || c.Value() != 1 {
+		t.Fatal("reset cursor should start over", c.Key(), c.Value())
+	}
+}
+
+func TestIterErrorWrapping(t *testing.T) {
+	fail := errors.New("fail")
+
+	bad := NewBaseIterator(NewListIterator(data), func(p Iterable) (interface{}, interface{}, error) {
+		if p.Value() == 56 {
+			return nil, nil, fail
+		}
+		return p.Value(), p.Key(), nil
+	})
+
+	layered := Map(Filter(bad, func(Iterable) bool { return true }), func(p Iterable) (interface{}, interface{}, error) {
+		return p.Value(), p.Key(), nil
+	})
+
+	c := NewCursor(layered)
+	n := 0
+	for c.Next() {
+		n++
+	}
+
+	err := c.Err()
+
+	if n != 2 || !errors.Is(err, fail) {
+		t.Fatal("cursor should stop on the failure", n, err)
+	}
+
+	var ie *IterError
+
+	if !errors.As(err, &ie) || ie.Index != 2 || ie.Key != 2 {
+		t.Fatal("error should hold the innermost position", err)
+	}
+
+	if _, err := Count(bad); !errors.Is(err, fail) {
+		t.Fatal("reducers should return the wrapped failure", err)
+	}
+
+	bv := IdentityIterator(NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		return nil, nil, ErrBADValue
+	}))
+
+	if err := bv.Next(); !errors.Is(err, ErrBADValue) || err == ErrBADValue {
+		t.Fatal("bad values should be wrapped with their position", err)
+	}
+
+	failing := Map(NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Length() >= 2 {
+			return nil, nil, fail
+		}
+		return p.Length(), p.Length(), nil
+	}), func(p Iterable) (interface{}, interface{}, error) {
+		return p.Value(), p.Key(), nil
+	})
+
+	c = NewCursor(failing)
+	for c.Next() {
+	}
+
+	if !errors.As(c.Err(), &ie) || ie.Index != 2 || ie.Key != nil {
+		t.Fatal("a failing parent should report the position it failed at, not its last key", c.Err())
+	}
+
+	if err := IdentityIterator(NewListIterator(nil)).Next(); err != ErrENDINDEX {
+		t.Fatal("a clean end should never be wrapped", err)
+	}
+}
@@ -0,0 +1,356 @@
+package sequence
+
+//FullPolicy decides what a bounded DequeSequence does when pushed while full
+type FullPolicy int
+
+const (
+	//RejectWhenFull fails pushes on a full deque with ErrFull
+	RejectWhenFull FullPolicy = iota
+	//OverwriteOldest drops items from the opposite end to make room, pushing
+	//to the back drops from the front and pushing to the front drops from
+	//the back
+	OverwriteOldest
+)
+
+//DequeSequence represents a double ended queue on a ring buffer with O(1)
+//pushes and pops at both ends
+type DequeSequence struct {
+	*Sequence
+	ring     []interface{}
+	head     int
+	size     int
+	capacity int
+	policy   FullPolicy
+	buffer   int
+}
+
+//NewDequeSequence returns a new unbounded DequeSequence holding data from
+//front to back
+func NewDequeSequence(data []interface{}, buff int) *DequeSequence {
+	d := &DequeSequence{
+		NewBaseSequence(buff, nil),
+		make([]interface{}, len(data)),
+		0,
+		0,
+		0,
+		RejectWhenFull,
+		buff,
+	}
+
+	d.size = copy(d.ring, data)
+	return d
+}
+
+//NewBoundedDequeSequence returns a new DequeSequence holding at most capacity
+//items, the policy decides what happens to pushes once it is full
+func NewBoundedDequeSequence(capacity int, policy FullPolicy, buff int) *DequeSequence {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &DequeSequence{
+		NewBaseSequence(buff, nil),
+		make([]interface{}, capacity),
+		0,
+		0,
+		capacity,
+		policy,
+		buff,
+	}
+}
+
+//slot returns the ring position of the i'th item from the front
+func (d *DequeSequence) slot(i int) int {
+	return (d.head + i) % len(d.ring)
+}
+
+//grow doubles the ring of an unbounded deque, the caller must hold the lock
+func (d *DequeSequence) grow() {
+	size := len(d.ring) * 2
+
+	if size == 0 {
+		size = MINBUFF
+	}
+
+	ring := make([]interface{}, size)
+
+	for i := 0; i < d.size; i++ {
+		ring[i] = d.ring[d.slot(i)]
+	}
+
+	d.ring = ring
+	d.head = 0
+}
+
+//room makes space for n more items or reports ErrFull, the caller must hold
+//the lock
+func (d *DequeSequence) room(n int, back bool) error {
+	if d.capacity == 0 {
+		for d.size+n > len(d.ring) {
+			d.grow()
+		}
+		return nil
+	}
+
+	if d.size+n <= d.capacity {
+		return nil
+	}
+
+	if d.policy == RejectWhenFull {
+		return ErrFull
+	}
+
+	for d.size > 0 && d.size+n > d.capacity {
+		if back {
+			d.popFront()
+		} else {
+			d.popBack()
+		}
+	}
+
+	return nil
+}
+
+func (d *DequeSequence) pushBack(v interface{}) {
+	d.ring[d.slot(d.size)] = v
+	d.size++
+}
+
+func (d *DequeSequence) pushFront(v interface{}) {
+	d.head = (d.head - 1 + len(d.ring)) % len(d.ring)
+	d.ring[d.head] = v
+	d.size++
+}
+
+func (d *DequeSequence) popFront() interface{} {
+	v := d.ring[d.head]
+	d.ring[d.head] = nil
+	d.head = d.slot(1)
+	d.size--
+	return v
+}
+
+func (d *DequeSequence) popBack() interface{} {
+	i := d.slot(d.size - 1)
+	v := d.ring[i]
+	d.ring[i] = nil
+	d.size--
+	return v
+}
+
+//PushBack adds the items to the back in order. A bounded deque with the
+//RejectWhenFull policy adds none of them and returns ErrFull if they do not
+//all fit, with OverwriteOldest only the last capacity items are kept
+func (d *DequeSequence) PushBack(f ...interface{}) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.capacity > 0 && d.policy == OverwriteOldest && len(f) > d.capacity {
+		f = f[len(f)-d.capacity:]
+	}
+
+	if err := d.room(len(f), true); err != nil {
+		return err
+	}
+
+	for _, v := range f {
+		d.pushBack(v)
+	}
+
+	return nil
+}
+
+//PushFront adds the items to the front in order, so the last one ends up
+//first. Bounded deques behave as with PushBack
+func (d *DequeSequence) PushFront(f ...interface{}) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.capacity > 0 && d.policy == OverwriteOldest && len(f) > d.capacity {
+		f = f[len(f)-d.capacity:]
+	}
+
+	if err := d.room(len(f), false); err != nil {
+		return err
+	}
+
+	for _, v := range f {
+		d.pushFront(v)
+	}
+
+	return nil
+}
+
+//PopFront removes and returns the front item or ErrEmpty
+func (d *DequeSequence) PopFront() (interface{}, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.size == 0 {
+		return nil, ErrEmpty
+	}
+
+	return d.popFront(), nil
+}
+
+//PopBack removes and returns the back item or ErrEmpty
+func (d *DequeSequence) PopBack() (interface{}, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.size == 0 {
+		return nil, ErrEmpty
+	}
+
+	return d.popBack(), nil
+}
+
+//PeekFront returns the front item without removing it or ErrEmpty
+func (d *DequeSequence) PeekFront() (interface{}, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if d.size == 0 {
+		return nil, ErrEmpty
+	}
+
+	return d.ring[d.head], nil
+}
+
+//PeekBack returns the back item without removing it or ErrEmpty
+func (d *DequeSequence) PeekBack() (interface{}, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if d.size == 0 {
+		return nil, ErrEmpty
+	}
+
+	return d.ring[d.slot(d.size-1)], nil
+}
+
+//Get retrieves the value at the position from the front, nil if the key is
+//not an int in range
+func (d *DequeSequence) Get(k interface{}) interface{} {
+	i, ok := k.(int)
+
+	if !ok {
+		return nil
+	}
+
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	if i < 0 || i >= d.size {
+		return nil
+	}
+
+	return d.ring[d.slot(i)]
+}
+
+//Capacity returns the bound of the deque or 0 if it is unbounded
+func (d *DequeSequence) Capacity() int {
+	return d.capacity
+}
+
+//Length returns length of data
+func (d *DequeSequence) Length() int {
+	d.lock.RLock()
+	sz := d.size
+	d.lock.RUnlock()
+	return sz
+}
+
+//Obj returns a copy of the items from front to back
+func (d *DequeSequence) Obj() []interface{} {
+	d.lock.RLock()
+	items := d.items()
+	d.lock.RUnlock()
+	return items
+}
+
+//items copies the items from front to back, the caller must hold the lock
+func (d *DequeSequence) items() []interface{} {
+	items := make([]interface{}, d.size)
+
+	for i := range items {
+		items[i] = d.ring[d.slot(i)]
+	}
+
+	return items
+}
+
+//Iterator returns an iterator over a snapshot of the deque from front to back
+func (d *DequeSequence) Iterator() Iterable {
+	return NewListIterator(d.Obj())
+}
+
+//ReverseIterator returns an iterator over a snapshot of the deque from back
+//to front
+func (d *DequeSequence) ReverseIterator() Iterable {
+	return NewReverseListIterator(d.Obj())
+}
+
+//Parent returns the sequence as a sequencable
+func (d *DequeSequence) Parent() Sequencable {
+	return Sequencable(d)
+}
+
+//Mutate allows mutation on a copy of the items from front to back, the
+//returned list becomes the new content. A bounded deque keeps the last
+//capacity items of it
+func (d *DequeSequence) Mutate(fn MutFunc) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	res, ok := fn(d.items()).([]interface{})
+
+	if !ok {
+		return
+	}
+
+	if d.capacity > 0 && len(res) > d.capacity {
+		res = res[len(res)-d.capacity:]
+	}
+
+	size := len(res)
+
+	if d.capacity > 0 {
+		size = d.capacity
+	}
+
+	d.ring = make([]interface{}, size)
+	d.head = 0
+	d.size = copy(d.ring, res)
+}
+
+//Clear wipes internal structure data
+func (d *DequeSequence) Clear() *DequeSequence {
+	d.lock.Lock()
+	for i := range d.ring {
+		d.ring[i] = nil
+	}
+	d.head = 0
+	d.size = 0
+	d.lock.Unlock()
+	return d
+}
+
+//Clone copies internal structure data
+func (d *DequeSequence) Clone() *DequeSequence {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	cl := &DequeSequence{
+		NewBaseSequence(d.buffer, nil),
+		make([]interface{}, len(d.ring)),
+		0,
+		0,
+		d.capacity,
+		d.policy,
+		d.buffer,
+	}
+
+	cl.size = copy(cl.ring, d.items())
+	return cl
+}
@@ -0,0 +1,131 @@
+package sequence
+
+import "sync"
+import "testing"
+
+func TestDequeSequence(t *testing.T) {
+	var seq MutableSizableSequencable = NewDequeSequence([]interface{}{2, 3}, 0)
+	d := seq.(*DequeSequence)
+
+	d.PushFront(1)
+	d.PushBack(4, 5)
+
+	if !sameValues(d.Obj(), []interface{}{1, 2, 3, 4, 5}) {
+		t.Fatal("deque order is incorrect", d.Obj())
+	}
+
+	if v, err := d.PopFront(); err != nil || v != 1 {
+		t.Fatal("pop front is incorrect", v, err)
+	}
+
+	if v, err := d.PopBack(); err != nil || v != 5 {
+		t.Fatal("pop back is incorrect", v, err)
+	}
+
+	if v, _ := d.PeekFront(); v != 2 {
+		t.Fatal("peek front is incorrect", v)
+	}
+
+	if v, _ := d.PeekBack(); v != 4 {
+		t.Fatal("peek back is incorrect", v)
+	}
+
+	if d.Get(1) != 3 || d.Get(9) != nil || d.Get("a") != nil {
+		t.Fatal("get is incorrect", d.Get(1))
+	}
+
+	if res := collect(d.ReverseIterator()); !sameValues(res, []interface{}{4, 3, 2}) {
+		t.Fatal("reverse iterator is incorrect", res)
+	}
+
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+		d.PopFront()
+	}
+
+	if d.Length() != 3 || d.Get(0) != 97 {
+		t.Fatal("ring should wrap around", d.Obj())
+	}
+
+	d.Clear()
+
+	if _, err := d.PopBack(); err != ErrEmpty {
+		t.Fatal("pop on an empty deque should return ErrEmpty", err)
+	}
+
+	if _, err := d.PeekFront(); err != ErrEmpty {
+		t.Fatal("peek on an empty deque should return ErrEmpty", err)
+	}
+}
+
+func TestBoundedDeque(t *testing.T) {
+	d := NewBoundedDequeSequence(3, RejectWhenFull, 0)
+
+	if err := d.PushBack(1, 2, 3); err != nil {
+		t.Fatal("deque should take items up to its capacity", err)
+	}
+
+	if err := d.PushFront(0); err != ErrFull {
+		t.Fatal("full deque should reject pushes", err)
+	}
+
+	if !sameValues(d.Obj(), []interface{}{1, 2, 3}) {
+		t.Fatal("rejected push should not change the deque", d.Obj())
+	}
+
+	o := NewBoundedDequeSequence(3, OverwriteOldest, 0)
+	o.PushBack(1, 2, 3, 4)
+
+	if !sameValues(o.Obj(), []interface{}{2, 3, 4}) {
+		t.Fatal("overwriting deque should drop the oldest", o.Obj())
+	}
+
+	o.PushFront(0)
+
+	if !sameValues(o.Obj(), []interface{}{0, 2, 3}) {
+		t.Fatal("pushing to the front should drop from the back", o.Obj())
+	}
+
+	cl := o.Clone()
+	cl.PushBack(9)
+
+	if cl.Capacity() != 3 || !sameValues(cl.Obj(), []interface{}{2, 3, 9}) || o.Length() != 3 {
+		t.Fatal("clone should keep the bound and policy", cl.Obj())
+	}
+
+	o.Mutate(func(f interface{}) interface{} {
+		return append(f.([]interface{}), 7, 8)
+	})
+
+	if !sameValues(o.Obj(), []interface{}{3, 7, 8}) {
+		t.Fatal("mutate should keep the last capacity items", o.Obj())
+	}
+}
+
+func TestDequeConcurrentQueue(t *testing.T) {
+	d := NewDequeSequence(nil, 0)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				d.PushBack(i)
+			}
+		}()
+	}
+
+	popped := 0
+	for popped < 400 {
+		if _, err := d.PopFront(); err == nil {
+			popped++
+		}
+	}
+
+	wg.Wait()
+
+	if d.Length() != 0 {
+		t.Fatal("every pushed item should be popped", d.Length())
+	}
+}
@@ -0,0 +1,40 @@
+package sequence
+
+import "errors"
+import "fmt"
+
+//IterError is a failure of an iterator along with the position it happened
+//at, it unwraps to the error it holds so errors.Is and errors.As see through
+//it to the sentinels. Index is the position that failed, Key is nil when the
+//parent failed before giving an item at that position
+type IterError struct {
+	Index int
+	Key   interface{}
+	Err   error
+}
+
+//Error describes the failure and its position
+func (e *IterError) Error() string {
+	return fmt.Sprintf("%v at index %d, key %v", e.Err, e.Index, e.Key)
+}
+
+//Unwrap returns the error the IterError holds
+func (e *IterError) Unwrap() error {
+	return e.Err
+}
+
+//wrapErr wraps a failure with its position. ErrENDINDEX is a clean end and is
+//never wrapped, errors already holding a position keep the innermost one
+func wrapErr(err error, index int, key interface{}) error {
+	if err == nil || errors.Is(err, ErrENDINDEX) {
+		return err
+	}
+
+	var ie *IterError
+
+	if errors.As(err, &ie) {
+		return err
+	}
+
+	return &IterError{index, key, err}
+}
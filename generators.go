@@ -0,0 +1,228 @@
+package sequence
+
+import "math"
+
+//Number is the constraint of the types Range and RangeInclusive count in
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+//Generator is a GenerativeIterator whoes state lives outside its ProcFunc,
+//so Reset can restore the initial state and Clone can copy the current one.
+//The copy is shallow, state holding maps, slices or pointers shares what
+//they point to
+type Generator[S any] struct {
+	*GenerativeIterator
+	init  func() S
+	state *S
+	build func(*S) ProcFunc
+	size  int
+}
+
+//newGenerator returns a Generator starting from the state init returns,
+//build makes the ProcFunc over a state and size is the number of values it
+//generates or UNKNOWNLENGTH when endless
+func newGenerator[S any](init func() S, size int, build func(*S) ProcFunc) *Generator[S] {
+	g := &Generator[S]{nil, init, nil, build, size}
+	g.Reset()
+	return g
+}
+
+//seeded returns an init function handing out the seed
+func seeded[S any](seed S) func() S {
+	return func() S {
+		return seed
+	}
+}
+
+//NewStatefulGenerator returns a generator of unknown length over the state
+//init returns, step moves the state on and returns the next key and value or
+//ErrENDINDEX once done. Reset re-runs init and Clone resumes from a copy of
+//the current state
+//
+//	fib := NewStatefulGenerator(func() [2]int { return [2]int{0, 1} },
+//		func(s *[2]int) (interface{}, interface{}, error) {
+//			v := s[0]
+//			*s = [2]int{s[1], s[0] + s[1]}
+//			return nil, v, nil
+//		})
+func NewStatefulGenerator[S any](init func() S, step func(*S) (interface{}, interface{}, error)) *Generator[S] {
+	return newGenerator(init, UNKNOWNLENGTH, func(state *S) ProcFunc {
+		return func(Iterable) (interface{}, interface{}, error) {
+			k, v, err := step(state)
+			return v, k, err
+		}
+	})
+}
+
+//Reset restarts the generator from its initial state
+func (g *Generator[S]) Reset() {
+	state := g.init()
+	g.state = &state
+	g.GenerativeIterator = NewGenerativeIterator(g.build(g.state))
+}
+
+//Length returns the number of values the generator makes in total or
+//UNKNOWNLENGTH when it is endless
+func (g *Generator[S]) Length() int {
+	if g.size < 0 {
+		return UNKNOWNLENGTH
+	}
+	return g.size
+}
+
+//Clone returns a generator carrying on from the current position and state
+func (g *Generator[S]) Clone() Iterable {
+	state := *g.state
+	gi := g.GenerativeIterator.Clone().(*GenerativeIterator)
+	gi.proc = g.build(&state)
+	return &Generator[S]{gi, g.init, &state, g.build, g.size}
+}
+
+//indexed returns a ProcFunc yielding at(i) for the first size positions, or
+//forever when size is negative
+func indexed(size int, at func(i int) interface{}) func(*struct{}) ProcFunc {
+	return func(*struct{}) ProcFunc {
+		return func(p Iterable) (interface{}, interface{}, error) {
+			i := p.Length()
+
+			if size >= 0 && i >= size {
+				return nil, nil, ErrENDINDEX
+			}
+
+			return at(i), i, nil
+		}
+	}
+}
+
+//rangeSize counts the steps from start that stay before end, or reach it
+//when inclusive
+func rangeSize[T Number](start, end, step T, inclusive bool) int {
+	var zero T
+
+	if step == zero {
+		return 0
+	}
+
+	within := func(n int) bool {
+		v := start + T(n)*step
+
+		switch {
+		case step > zero && inclusive:
+			return v <= end && v >= start
+		case step > zero:
+			return v < end && v >= start
+		case inclusive:
+			return v >= end && v <= start
+		}
+		return v > end && v <= start
+	}
+
+	n := int(math.Ceil((float64(end) - float64(start)) / float64(step)))
+
+	if n < 0 {
+		n = 0
+	}
+
+	for n > 0 && !within(n-1) {
+		n--
+	}
+
+	for within(n) {
+		n++
+	}
+
+	return n
+}
+
+//Range returns a generator counting from start towards end by step, end
+//excluded. A step of zero or one pointing away from end yields nothing
+func Range[T Number](start, end, step T) *Generator[struct{}] {
+	size := rangeSize(start, end, step, false)
+
+	return newGenerator(seeded(struct{}{}), size, indexed(size, func(i int) interface{} {
+		return start + T(i)*step
+	}))
+}
+
+//RangeInclusive returns a generator counting from start towards end by step,
+//end included when a step lands on it. Float steps that do not land on end
+//exactly, like 0.1, may stop a step short; use Linspace for those
+func RangeInclusive[T Number](start, end, step T) *Generator[struct{}] {
+	size := rangeSize(start, end, step, true)
+
+	return newGenerator(seeded(struct{}{}), size, indexed(size, func(i int) interface{} {
+		return start + T(i)*step
+	}))
+}
+
+//Repeat returns a generator yielding v n times, or forever when n is
+//negative
+func Repeat(v interface{}, n int) *Generator[struct{}] {
+	if n < 0 {
+		n = UNKNOWNLENGTH
+	}
+
+	return newGenerator(seeded(struct{}{}), n, indexed(n, func(int) interface{} {
+		return v
+	}))
+}
+
+//Linspace returns a generator yielding n evenly spaced values from start to
+//end, both included
+func Linspace(start, end float64, n int) *Generator[struct{}] {
+	if n < 0 {
+		n = 0
+	}
+
+	return newGenerator(seeded(struct{}{}), n, indexed(n, func(i int) interface{} {
+		if i == n-1 && n > 1 {
+			return end
+		}
+
+		if n == 1 {
+			return start
+		}
+
+		return start + (end-start)*float64(i)/float64(n-1)
+	}))
+}
+
+//Iterate returns an endless generator yielding seed, fn(seed),
+//fn(fn(seed)) and so on
+func Iterate[T any](seed T, fn func(T) T) *Generator[T] {
+	return newGenerator(seeded(seed), UNKNOWNLENGTH, func(state *T) ProcFunc {
+		return func(p Iterable) (interface{}, interface{}, error) {
+			i := p.Length()
+
+			if i > 0 {
+				*state = fn(*state)
+			}
+
+			return *state, i, nil
+		}
+	})
+}
+
+//Unfold returns a generator driven by a state, fn turns the current state
+//into a value and the next state and ends the generator by returning false
+//
+//	fib := Unfold([2]int{0, 1}, func(s [2]int) (interface{}, [2]int, bool) {
+//		return s[0], [2]int{s[1], s[0] + s[1]}, true
+//	})
+func Unfold[S any](seed S, fn func(S) (interface{}, S, bool)) *Generator[S] {
+	return newGenerator(seeded(seed), UNKNOWNLENGTH, func(state *S) ProcFunc {
+		return func(p Iterable) (interface{}, interface{}, error) {
+			v, next, ok := fn(*state)
+
+			if !ok {
+				return nil, nil, ErrENDINDEX
+			}
+
+			*state = next
+			return v, p.Length(), nil
+		}
+	})
+}
@@ -0,0 +1,183 @@
+package sequence
+
+import "testing"
+
+func TestRange(t *testing.T) {
+	r := Range(0, 10, 3)
+
+	if r.Length() != 4 {
+		t.Fatal("range length is incorrect", r.Length())
+	}
+
+	if res := collect(r); !sameValues(res, []interface{}{0, 3, 6, 9}) {
+		t.Fatal("range values are incorrect", res)
+	}
+
+	if res := collect(RangeInclusive(10, 0, -5)); !sameValues(res, []interface{}{10, 5, 0}) {
+		t.Fatal("inclusive downward range is incorrect", res)
+	}
+
+	if res := collect(Range(0.0, 1.0, 0.25)); !sameValues(res, []interface{}{0.0, 0.25, 0.5, 0.75}) {
+		t.Fatal("float range is incorrect", res)
+	}
+
+	if res := collect(RangeInclusive(0.0, 1.0, 0.25)); !sameValues(res, []interface{}{0.0, 0.25, 0.5, 0.75, 1.0}) {
+		t.Fatal("inclusive float range should reach its end", res)
+	}
+
+	if Range(0, 5, 0).Length() != 0 || Range(5, 0, 1).Length() != 0 || Range(uint(5), 0, 1).Length() != 0 {
+		t.Fatal("ranges that never reach their end should be empty")
+	}
+
+	if keys := keysOf(Range(5, 8, 1)); !sameValues(keys, []interface{}{0, 1, 2}) {
+		t.Fatal("range keys should be positions", keys)
+	}
+}
+
+func TestRepeatAndLinspace(t *testing.T) {
+	if res := collect(Repeat("a", 3)); !sameValues(res, []interface{}{"a", "a", "a"}) {
+		t.Fatal("repeat is incorrect", res)
+	}
+
+	if Repeat("a", -1).Length() != UNKNOWNLENGTH {
+		t.Fatal("endless repeat should have an unknown length")
+	}
+
+	if res := collect(Take(Repeat(1, -1), 5)); len(res) != 5 {
+		t.Fatal("endless repeat should keep going", res)
+	}
+
+	if res := collect(Linspace(0, 1, 5)); !sameValues(res, []interface{}{0.0, 0.25, 0.5, 0.75, 1.0}) {
+		t.Fatal("linspace is incorrect", res)
+	}
+
+	if res := collect(Linspace(2, 3, 1)); !sameValues(res, []interface{}{2.0}) {
+		t.Fatal("linspace of one point should give its start", res)
+	}
+}
+
+func TestIterateAndUnfold(t *testing.T) {
+	pow := Iterate(1, func(v int) int { return v * 2 })
+
+	if res := collect(Take(pow, 5)); !sameValues(res, []interface{}{1, 2, 4, 8, 16}) {
+		t.Fatal("iterate is incorrect", res)
+	}
+
+	fib := Unfold([2]int{0, 1}, func(s [2]int) (interface{}, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, true
+	})
+
+	if res := collect(Take(fib, 8)); !sameValues(res, []interface{}{0, 1, 1, 2, 3, 5, 8, 13}) {
+		t.Fatal("unfold is incorrect", res)
+	}
+
+	countdown := Unfold(3, func(s int) (interface{}, int, bool) {
+		return s, s - 1, s > 0
+	})
+
+	if res := collect(countdown); !sameValues(res, []interface{}{3, 2, 1}) {
+		t.Fatal("unfold should end when fn says so", res)
+	}
+}
+
+func TestGeneratorResetAndClone(t *testing.T) {
+	fib := Unfold([2]int{0, 1}, func(s [2]int) (interface{}, [2]int, bool) {
+		return s[0], [2]int{s[1], s[0] + s[1]}, true
+	})
+
+	for i := 0; i < 5; i++ {
+		fib.Next()
+	}
+
+	cl := fib.Clone()
+
+	if cl.Next() != nil || cl.Value() != 5 || cl.Key() != 5 {
+		t.Fatal("clone should carry on from the current state", cl.Value(), cl.Key())
+	}
+
+	if fib.Next() != nil || fib.Value() != 5 {
+		t.Fatal("clone should not move its source", fib.Value())
+	}
+
+	fib.Reset()
+
+	if fib.Next() != nil || fib.Value() != 0 {
+		t.Fatal("reset should restart from the seed", fib.Value())
+	}
+
+	r := Range(0, 4, 1)
+	r.Next()
+	r.Next()
+
+	if res := collect(r.Clone()); !sameValues(res, []interface{}{2, 3}) {
+		t.Fatal("range clone should carry on from its position", res)
+	}
+
+	if res := collect(Take(r, 5)); !sameValues(res, []interface{}{2, 3}) {
+		t.Fatal("combinators over a generator should carry on from its position", res)
+	}
+
+	for i := 0; i < 2; i++ {
+		var ranged []interface{}
+		for v := range Seq(r) {
+			ranged = append(ranged, v)
+		}
+
+		if !sameValues(ranged, []interface{}{2, 3}) {
+			t.Fatal("every range over a generator should start from its position", ranged)
+		}
+	}
+
+	r.Reset()
+
+	if res := collect(r); !sameValues(res, []interface{}{0, 1, 2, 3}) {
+		t.Fatal("reset range should start over", res)
+	}
+}
+
+func TestStatefulGenerator(t *testing.T) {
+	inits := 0
+
+	fib := NewStatefulGenerator(func() [2]int {
+		inits++
+		return [2]int{0, 1}
+	}, func(s *[2]int) (interface{}, interface{}, error) {
+		if s[0] > 10 {
+			return nil, nil, ErrENDINDEX
+		}
+
+		v := s[0]
+		*s = [2]int{s[1], s[0] + s[1]}
+		return v, v, nil
+	})
+
+	if fib.Length() != UNKNOWNLENGTH {
+		t.Fatal("stateful generator should have an unknown length", fib.Length())
+	}
+
+	for i := 0; i < 4; i++ {
+		fib.Next()
+	}
+
+	if fib.Value() != 2 || fib.Key() != 2 {
+		t.Fatal("step should give the key and value", fib.Key(), fib.Value())
+	}
+
+	if res := collect(fib.Clone()); !sameValues(res, []interface{}{3, 5, 8}) {
+		t.Fatal("clone should resume from a copy of the state", res)
+	}
+
+	if res := collect(fib); !sameValues(res, []interface{}{3, 5, 8}) {
+		t.Fatal("clone should not share the state", res)
+	}
+
+	if err := fib.Next(); err != ErrENDINDEX {
+		t.Fatal("ended generator should keep returning ErrENDINDEX", err)
+	}
+
+	fib.Reset()
+
+	if inits != 2 || fib.Next() != nil || fib.Value() != 0 {
+		t.Fatal("reset should re-run init", inits, fib.Value())
+	}
+}
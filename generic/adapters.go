@@ -0,0 +1,201 @@
+package generic
+
+import "github.com/influx6/sequence"
+
+//cast converts an untyped value into T, nil converts into the zero value of T
+func cast[T any](v interface{}) (T, bool) {
+	var zero T
+
+	if v == nil {
+		return zero, true
+	}
+
+	t, ok := v.(T)
+	return t, ok
+}
+
+//TypedIterator exposes a sequence.Iterable as a type safe Iterator
+type TypedIterator[K, V any] struct {
+	root  sequence.Iterable
+	key   K
+	value V
+}
+
+//FromIterable returns a type safe Iterator over a sequence.Iterable. Next
+//returns sequence.ErrBADValue when a key or value is not of the expected type
+func FromIterable[K, V any](it sequence.Iterable) *TypedIterator[K, V] {
+	return &TypedIterator[K, V]{root: it}
+}
+
+//Next moves to the next item
+func (t *TypedIterator[K, V]) Next() error {
+	if err := t.root.Next(); err != nil {
+		return err
+	}
+
+	k, ok := cast[K](t.root.Key())
+
+	if !ok {
+		return sequence.ErrBADValue
+	}
+
+	v, ok := cast[V](t.root.Value())
+
+	if !ok {
+		return sequence.ErrBADValue
+	}
+
+	t.key = k
+	t.value = v
+	return nil
+}
+
+//Reset reverst the iterators index
+func (t *TypedIterator[K, V]) Reset() {
+	var zk K
+	var zv V
+	t.root.Reset()
+	t.key = zk
+	t.value = zv
+}
+
+//Key returns the current index of the iterator
+func (t *TypedIterator[K, V]) Key() K {
+	return t.key
+}
+
+//Value returns the value of the data with the index value
+func (t *TypedIterator[K, V]) Value() V {
+	return t.value
+}
+
+//Length returns the wrapped iterators length
+func (t *TypedIterator[K, V]) Length() int {
+	return t.root.Length()
+}
+
+//Clone returns a new iterator off that data
+func (t *TypedIterator[K, V]) Clone() Iterator[K, V] {
+	return FromIterable[K, V](t.root.Clone())
+}
+
+//UntypedIterator exposes a type safe Iterator as a sequence.Iterable
+type UntypedIterator[K, V any] struct {
+	root Iterator[K, V]
+	used bool
+}
+
+//ToIterable returns a sequence.Iterable over a type safe Iterator
+func ToIterable[K, V any](it Iterator[K, V]) *UntypedIterator[K, V] {
+	return &UntypedIterator[K, V]{root: it}
+}
+
+//Next moves to the next item
+func (u *UntypedIterator[K, V]) Next() error {
+	err := u.root.Next()
+
+	if err == nil {
+		u.used = true
+	}
+
+	return err
+}
+
+//Reset reverst the iterators index
+func (u *UntypedIterator[K, V]) Reset() {
+	u.root.Reset()
+	u.used = false
+}
+
+//Key returns the current index of the iterator, nil until Next is called
+func (u *UntypedIterator[K, V]) Key() interface{} {
+	if !u.used {
+		return nil
+	}
+	return u.root.Key()
+}
+
+//Value returns the value of the data with the index value, nil until Next is
+//called
+func (u *UntypedIterator[K, V]) Value() interface{} {
+	if !u.used {
+		return nil
+	}
+	return u.root.Value()
+}
+
+//Length returns the wrapped iterators length
+func (u *UntypedIterator[K, V]) Length() int {
+	return u.root.Length()
+}
+
+//Clone returns a new iterator off that data
+func (u *UntypedIterator[K, V]) Clone() sequence.Iterable {
+	return ToIterable(u.root.Clone())
+}
+
+//FromListSequence copies a sequence.ListSequencable into a ListSequence,
+//failing with sequence.ErrBADValue if any item is not a T
+func FromListSequence[T any](s sequence.ListSequencable, buff int) (*ListSequence[T], error) {
+	it := FromIterable[int, T](s.Iterator())
+	ls := NewListSequence[T](nil, buff)
+
+	for {
+		err := it.Next()
+
+		if err == sequence.ErrENDINDEX {
+			return ls, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ls.Add(it.Value())
+	}
+}
+
+//ToListSequence copies the ListSequence into a sequence.ListSequence
+func ToListSequence[T any](l *ListSequence[T]) *sequence.ListSequence {
+	items := l.Obj()
+	data := make([]interface{}, len(items))
+
+	for i, v := range items {
+		data[i] = v
+	}
+
+	return sequence.NewListSequence(data, l.buffer)
+}
+
+//FromMapSequence copies a sequence.MapSequencable into a MapSequence, failing
+//with sequence.ErrBADValue if any key or value is not of the expected type
+func FromMapSequence[K comparable, V any](s sequence.MapSequencable, buff int) (*MapSequence[K, V], error) {
+	it := FromIterable[K, V](s.Iterator())
+	ms := NewMapSequence[K, V](nil, buff)
+
+	for {
+		err := it.Next()
+
+		if err == sequence.ErrENDINDEX {
+			return ms, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		ms.Add(it.Key(), it.Value())
+	}
+}
+
+//ToMapSequence copies the MapSequence into a sequence.MapSequence
+func ToMapSequence[K comparable, V any](m *MapSequence[K, V]) *sequence.MapSequence {
+	items := m.Obj()
+	data := make(map[interface{}]interface{}, len(items))
+
+	for k, v := range items {
+		data[k] = v
+	}
+
+	return sequence.NewMapSequence(data, m.buffer)
+}
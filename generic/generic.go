@@ -0,0 +1,569 @@
+//Package generic provides type safe versions of the sequence structures and
+//iterators, along with adapters to and from the sequence.Iterable interface
+//so code can move between the two one package at a time
+package generic
+
+import "sync"
+
+import "github.com/influx6/sequence"
+
+//Iterator defines the type safe sequence method rules
+type Iterator[K, V any] interface {
+	Next() error
+	Key() K
+	Value() V
+	Reset()
+	Length() int
+	Clone() Iterator[K, V]
+}
+
+//ProcFunc is the type of a function giving to a BaseIterator, returning the
+//new value and key in the same order as sequence.ProcFunc
+type ProcFunc[K, V, RK, RV any] func(f Iterator[K, V]) (RV, RK, error)
+
+//ListIterator handles interation over slices
+type ListIterator[T any] struct {
+	data  []T
+	index int
+}
+
+//NewListIterator returns a new iterator for the slice
+func NewListIterator[T any](b []T) *ListIterator[T] {
+	return &ListIterator[T]{b, -1}
+}
+
+//Next moves to the next item
+func (l *ListIterator[T]) Next() error {
+	if l.index >= len(l.data)-1 {
+		return sequence.ErrENDINDEX
+	}
+	l.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (l *ListIterator[T]) Reset() {
+	l.index = -1
+}
+
+//Key returns the current index of the iterator
+func (l *ListIterator[T]) Key() int {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *ListIterator[T]) Value() T {
+	var zero T
+
+	if l.index < 0 {
+		return zero
+	}
+
+	return l.data[l.index]
+}
+
+//Length returns the iterators targets length,not its operation length
+func (l *ListIterator[T]) Length() int {
+	return len(l.data)
+}
+
+//Clone returns a new iterable off this iterators data
+func (l *ListIterator[T]) Clone() Iterator[int, T] {
+	return NewListIterator(l.data)
+}
+
+//ReverseListIterator walks a slice from its last item to its first
+type ReverseListIterator[T any] struct {
+	*ListIterator[T]
+}
+
+//NewReverseListIterator returns a new reverse interator
+func NewReverseListIterator[T any](b []T) *ReverseListIterator[T] {
+	return &ReverseListIterator[T]{NewListIterator(b)}
+}
+
+//Key returns the current index of the iterator
+func (r *ReverseListIterator[T]) Key() int {
+	if r.index < 0 {
+		return -1
+	}
+	return (len(r.data) - 1) - r.index
+}
+
+//Value returns the value of the data with the index value
+func (r *ReverseListIterator[T]) Value() T {
+	var zero T
+	k := r.Key()
+
+	if k < 0 {
+		return zero
+	}
+
+	return r.data[k]
+}
+
+//Clone returns a new iterator off that data
+func (r *ReverseListIterator[T]) Clone() Iterator[int, T] {
+	return NewReverseListIterator(r.data)
+}
+
+//MapIterator provides an iterator for maps over a snapshot of its keys
+type MapIterator[K comparable, V any] struct {
+	data map[K]V
+	keys *ListIterator[K]
+}
+
+//GrabKeys returns a list of the given map keys
+func GrabKeys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+//NewMapIterator returns a new mapiterator for use
+func NewMapIterator[K comparable, V any](m map[K]V) *MapIterator[K, V] {
+	return &MapIterator[K, V]{m, NewListIterator(GrabKeys(m))}
+}
+
+//Next moves to the next item
+func (m *MapIterator[K, V]) Next() error {
+	return m.keys.Next()
+}
+
+//Reset reverst the iterators index
+func (m *MapIterator[K, V]) Reset() {
+	m.keys.Reset()
+}
+
+//Key returns the current key of the iterator
+func (m *MapIterator[K, V]) Key() K {
+	return m.keys.Value()
+}
+
+//Value returns the current value of the iterator
+func (m *MapIterator[K, V]) Value() V {
+	var zero V
+
+	if m.keys.Key() < 0 {
+		return zero
+	}
+
+	return m.data[m.Key()]
+}
+
+//Length returns the iterators targets length,not its operation length
+func (m *MapIterator[K, V]) Length() int {
+	return len(m.data)
+}
+
+//Clone returns a new iterator off that data
+func (m *MapIterator[K, V]) Clone() Iterator[K, V] {
+	return NewMapIterator(m.data)
+}
+
+//GenerativeIterator is the base iterator for creating custom iterator
+//behaviours
+type GenerativeIterator[K, V any] struct {
+	proc  func(Iterator[K, V]) (V, K, error)
+	value V
+	index K
+	can   bool
+	count int
+}
+
+//NewGenerativeIterator returns a new GenerativeIterator
+func NewGenerativeIterator[K, V any](p func(Iterator[K, V]) (V, K, error)) *GenerativeIterator[K, V] {
+	return &GenerativeIterator[K, V]{proc: p, can: true}
+}
+
+//Next moves to the next item
+func (l *GenerativeIterator[K, V]) Next() error {
+	if !l.can {
+		return sequence.ErrENDINDEX
+	}
+
+	v, k, err := l.proc(l)
+
+	if err == sequence.ErrBADValue {
+		var zv V
+		var zk K
+		l.value = zv
+		l.index = zk
+		l.can = false
+		return err
+	}
+
+	if err == sequence.ErrENDINDEX {
+		l.can = false
+		return err
+	}
+
+	l.value = v
+	l.index = k
+	l.count++
+	return err
+}
+
+//Reset reverst the iterators index
+func (l *GenerativeIterator[K, V]) Reset() {
+	var zv V
+	var zk K
+	l.value = zv
+	l.index = zk
+	l.can = true
+	l.count = 0
+}
+
+//Key returns the current index of the iterator
+func (l *GenerativeIterator[K, V]) Key() K {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *GenerativeIterator[K, V]) Value() V {
+	return l.value
+}
+
+//Length returns the total time this iterator as generated values
+func (l *GenerativeIterator[K, V]) Length() int {
+	return l.count
+}
+
+//Clone returns a new iterator carrying on from the current position, it
+//shares any state captured by the proc
+func (l *GenerativeIterator[K, V]) Clone() Iterator[K, V] {
+	gi := *l
+	return &gi
+}
+
+//BaseIterator handles interation over an iterator, turning each of its items
+//into a new key and value
+type BaseIterator[K, V, RK, RV any] struct {
+	parent Iterator[K, V]
+	value  RV
+	index  RK
+	proc   ProcFunc[K, V, RK, RV]
+}
+
+//NewBaseIterator returns a base iterator based on a function evaluator
+func NewBaseIterator[K, V, RK, RV any](b Iterator[K, V], fn ProcFunc[K, V, RK, RV]) *BaseIterator[K, V, RK, RV] {
+	return &BaseIterator[K, V, RK, RV]{parent: b.Clone(), proc: fn}
+}
+
+//IdentityIterator takes an Iterator and returns an iterator that simple
+//returns the root iterators key and value without change
+func IdentityIterator[K, V any](b Iterator[K, V]) *BaseIterator[K, V, K, V] {
+	return NewBaseIterator(b, func(root Iterator[K, V]) (V, K, error) {
+		return root.Value(), root.Key(), nil
+	})
+}
+
+//Next moves to the next item
+func (l *BaseIterator[K, V, RK, RV]) Next() error {
+	err := l.parent.Next()
+
+	if err == sequence.ErrBADValue {
+		l.clear()
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	v, k, err := l.proc(l.parent)
+
+	if err != nil {
+		return err
+	}
+
+	l.value = v
+	l.index = k
+	return nil
+}
+
+func (l *BaseIterator[K, V, RK, RV]) clear() {
+	var zv RV
+	var zk RK
+	l.value = zv
+	l.index = zk
+}
+
+//Reset reverst the iterators index
+func (l *BaseIterator[K, V, RK, RV]) Reset() {
+	l.parent.Reset()
+	l.clear()
+}
+
+//Key returns the current index of the iterator
+func (l *BaseIterator[K, V, RK, RV]) Key() RK {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *BaseIterator[K, V, RK, RV]) Value() RV {
+	return l.value
+}
+
+//Length returns the parent iterators targets length,not its operation length
+func (l *BaseIterator[K, V, RK, RV]) Length() int {
+	return l.parent.Length()
+}
+
+//Clone returns a new iterator off that data
+func (l *BaseIterator[K, V, RK, RV]) Clone() Iterator[RK, RV] {
+	return NewBaseIterator(l.parent, l.proc)
+}
+
+//ListSequence represents a type safe sequence for slices
+type ListSequence[T any] struct {
+	lock   sync.RWMutex
+	data   []T
+	buffer int
+}
+
+//NewListSequence returns a new ListSequence
+func NewListSequence[T any](data []T, buff int) *ListSequence[T] {
+	if data == nil {
+		data = make([]T, 0)
+	}
+
+	if buff < sequence.MINBUFF {
+		buff = sequence.MINBUFF
+	}
+
+	return &ListSequence[T]{data: data, buffer: buff}
+}
+
+//Iterator returns an iterator over the current items of the sequence, later
+//writes to the sequence are not seen by it
+func (l *ListSequence[T]) Iterator() Iterator[int, T] {
+	return NewListIterator(l.Obj())
+}
+
+//Obj returns the sequence data in the format of its input
+func (l *ListSequence[T]) Obj() []T {
+	l.lock.RLock()
+	d := l.data[:len(l.data):len(l.data)]
+	l.lock.RUnlock()
+	return d
+}
+
+//Mutate allows mutation on a copy of the sequence data, the returned slice
+//becomes the new data
+func (l *ListSequence[T]) Mutate(fn func([]T) []T) {
+	l.lock.Lock()
+	nd := make([]T, len(l.data))
+	copy(nd, l.data)
+	l.data = fn(nd)
+	l.lock.Unlock()
+}
+
+//Get retrieves the value at the index and reports if the index was in range
+func (l *ListSequence[T]) Get(i int) (T, bool) {
+	var zero T
+
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if i < 0 || i >= len(l.data) {
+		return zero, false
+	}
+
+	return l.data[i], true
+}
+
+//Add for the ListSequence adds all supplied arguments at once to the list
+func (l *ListSequence[T]) Add(f ...T) *ListSequence[T] {
+	l.lock.Lock()
+	l.data = append(l.data, f...)
+	l.lock.Unlock()
+	return l
+}
+
+//Delete removes the items at the supplied indexes, indexes out of range are
+//ignored
+func (l *ListSequence[T]) Delete(f ...int) *ListSequence[T] {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	drop := make(map[int]bool, len(f))
+	for _, i := range f {
+		drop[i] = true
+	}
+
+	nd := make([]T, 0, len(l.data))
+	for i, v := range l.data {
+		if !drop[i] {
+			nd = append(nd, v)
+		}
+	}
+
+	l.data = nd
+	return l
+}
+
+//Clear wipes internal structure data
+func (l *ListSequence[T]) Clear() *ListSequence[T] {
+	l.lock.Lock()
+	l.data = make([]T, 0)
+	l.lock.Unlock()
+	return l
+}
+
+//Clone copies internal structure data
+func (l *ListSequence[T]) Clone() *ListSequence[T] {
+	l.lock.RLock()
+	nd := make([]T, len(l.data))
+	copy(nd, l.data)
+	l.lock.RUnlock()
+	return NewListSequence(nd, l.buffer)
+}
+
+//Length returns length of data
+func (l *ListSequence[T]) Length() int {
+	l.lock.RLock()
+	sz := len(l.data)
+	l.lock.RUnlock()
+	return sz
+}
+
+//Keys returns the indexes of the sequence as a sequence
+func (l *ListSequence[T]) Keys() *ListSequence[int] {
+	sz := l.Length()
+	keys := make([]int, sz)
+
+	for i := range keys {
+		keys[i] = i
+	}
+
+	return NewListSequence(keys, l.buffer)
+}
+
+//Values returns the value of these sequence as a sequence
+func (l *ListSequence[T]) Values() *ListSequence[T] {
+	return l
+}
+
+//MapSequence represents a type safe sequence for maps
+type MapSequence[K comparable, V any] struct {
+	lock   sync.RWMutex
+	data   map[K]V
+	buffer int
+}
+
+//NewMapSequence returns a new MapSequence
+func NewMapSequence[K comparable, V any](data map[K]V, buff int) *MapSequence[K, V] {
+	if data == nil {
+		data = make(map[K]V)
+	}
+
+	if buff < sequence.MINBUFF {
+		buff = sequence.MINBUFF
+	}
+
+	return &MapSequence[K, V]{data: data, buffer: buff}
+}
+
+//Iterator returns an iterator over a copy of the sequence data
+func (l *MapSequence[K, V]) Iterator() Iterator[K, V] {
+	return NewMapIterator(l.Obj())
+}
+
+//Obj returns a copy of the sequence data
+func (l *MapSequence[K, V]) Obj() map[K]V {
+	l.lock.RLock()
+	m := make(map[K]V, len(l.data))
+	for k, v := range l.data {
+		m[k] = v
+	}
+	l.lock.RUnlock()
+	return m
+}
+
+//Mutate allows mutation on a copy of the sequence data, the returned map
+//becomes the new data
+func (l *MapSequence[K, V]) Mutate(fn func(map[K]V) map[K]V) {
+	l.lock.Lock()
+	m := make(map[K]V, len(l.data))
+	for k, v := range l.data {
+		m[k] = v
+	}
+	l.data = fn(m)
+	l.lock.Unlock()
+}
+
+//Get retrieves the value for the key and reports if the key was present
+func (l *MapSequence[K, V]) Get(k K) (V, bool) {
+	l.lock.RLock()
+	v, ok := l.data[k]
+	l.lock.RUnlock()
+	return v, ok
+}
+
+//Add sets the value for the key
+func (l *MapSequence[K, V]) Add(k K, v V) *MapSequence[K, V] {
+	l.lock.Lock()
+	l.data[k] = v
+	l.lock.Unlock()
+	return l
+}
+
+//Delete removes the supplied keys
+func (l *MapSequence[K, V]) Delete(f ...K) *MapSequence[K, V] {
+	l.lock.Lock()
+	for _, k := range f {
+		delete(l.data, k)
+	}
+	l.lock.Unlock()
+	return l
+}
+
+//Clear wipes internal structure data
+func (l *MapSequence[K, V]) Clear() *MapSequence[K, V] {
+	l.lock.Lock()
+	l.data = make(map[K]V)
+	l.lock.Unlock()
+	return l
+}
+
+//Clone copies internal structure data
+func (l *MapSequence[K, V]) Clone() *MapSequence[K, V] {
+	return NewMapSequence(l.Obj(), l.buffer)
+}
+
+//Length returns length of data
+func (l *MapSequence[K, V]) Length() int {
+	l.lock.RLock()
+	sz := len(l.data)
+	l.lock.RUnlock()
+	return sz
+}
+
+//Keys returns the keys of the sequence as a sequence
+func (l *MapSequence[K, V]) Keys() *ListSequence[K] {
+	kl := NewListSequence[K](nil, l.buffer)
+	it := l.Iterator()
+
+	for it.Next() == nil {
+		kl.Add(it.Key())
+	}
+
+	return kl
+}
+
+//Values returns the values of the sequence as a sequence
+func (l *MapSequence[K, V]) Values() *ListSequence[V] {
+	vl := NewListSequence[V](nil, l.buffer)
+	it := l.Iterator()
+
+	for it.Next() == nil {
+		vl.Add(it.Value())
+	}
+
+	return vl
+}
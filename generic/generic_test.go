@@ -0,0 +1,192 @@
+package generic
+
+import "testing"
+
+import "github.com/influx6/sequence"
+
+func TestListIterator(t *testing.T) {
+	data := []int{1, 32, 56, 7}
+	li := NewListIterator(data)
+
+	for li.Next() == nil {
+		if li.Value() != data[li.Key()] {
+			t.Fatal("Index and value incorrect with list", li.Key(), li.Value(), data)
+		}
+	}
+
+	ri := NewReverseListIterator(data)
+
+	if err := ri.Next(); err != nil || ri.Key() != 3 || ri.Value() != 7 {
+		t.Fatal("reverse iterator should start at the last item", ri.Key(), ri.Value(), err)
+	}
+}
+
+func TestBaseIterator(t *testing.T) {
+	li := NewListIterator([]int{1, 2, 3})
+	bl := NewBaseIterator(Iterator[int, int](li), func(f Iterator[int, int]) (string, int, error) {
+		return string(rune('a' + f.Value() - 1)), f.Key(), nil
+	})
+
+	var res string
+	for bl.Next() == nil {
+		res += bl.Value()
+	}
+
+	if res != "abc" {
+		t.Fatal("base iterator did not transform the values", res)
+	}
+
+	bl.Reset()
+
+	if err := bl.Next(); err != nil || bl.Value() != "a" {
+		t.Fatal("reset base iterator should start again", bl.Value(), err)
+	}
+}
+
+func TestGenerativeIterator(t *testing.T) {
+	incr := NewGenerativeIterator(func(p Iterator[int, int]) (int, int, error) {
+		if p.Length() >= 5 {
+			return 0, 0, sequence.ErrENDINDEX
+		}
+		return p.Length() * 2, p.Length(), nil
+	})
+
+	sum := 0
+	for incr.Next() == nil {
+		sum += incr.Value()
+	}
+
+	if sum != 20 {
+		t.Fatal("generated values are incorrect", sum)
+	}
+
+	if err := incr.Next(); err != sequence.ErrENDINDEX {
+		t.Fatal("ended generator should keep returning ErrENDINDEX", err)
+	}
+
+	incr.Reset()
+
+	if err := incr.Next(); err != nil || incr.Value() != 0 {
+		t.Fatal("reset generator should start again", incr.Value(), err)
+	}
+
+	cl := incr.Clone()
+
+	if err := cl.Next(); err != nil || cl.Value() != 2 || incr.Value() != 0 {
+		t.Fatal("clone should carry on from the current position", cl.Value(), err)
+	}
+}
+
+func TestListSequence(t *testing.T) {
+	ls := NewListSequence[int](nil, 0)
+	ls.Add(1, 2, 4, 5)
+	ls.Delete(2)
+
+	if ls.Length() != 3 {
+		t.Fatal("list should hold 3 items", ls.Length())
+	}
+
+	if v, ok := ls.Get(2); !ok || v != 5 {
+		t.Fatal("value at index 2 should be 5", v, ok)
+	}
+
+	if _, ok := ls.Get(10); ok {
+		t.Fatal("out of range index should not be found")
+	}
+
+	it := ls.Iterator()
+	ls.Add(9)
+
+	n := 0
+	for it.Next() == nil {
+		n++
+	}
+
+	if n != 3 {
+		t.Fatal("iterator should not see later writes", n)
+	}
+
+	ls.Mutate(func(d []int) []int {
+		return d[:1]
+	})
+
+	if cl := ls.Clone(); cl.Length() != 1 || ls.Keys().Length() != 1 {
+		t.Fatal("mutated list should hold 1 item", cl.Length())
+	}
+}
+
+func TestMapSequence(t *testing.T) {
+	ms := NewMapSequence[string, int](nil, 0)
+	ms.Add("a", 1).Add("b", 2).Add("c", 3)
+	ms.Delete("b")
+
+	if v, ok := ms.Get("c"); !ok || v != 3 {
+		t.Fatal("value for c should be 3", v, ok)
+	}
+
+	if _, ok := ms.Get("b"); ok {
+		t.Fatal("deleted key should not be found")
+	}
+
+	total := 0
+	it := ms.Iterator()
+	for it.Next() == nil {
+		if v, _ := ms.Get(it.Key()); v != it.Value() {
+			t.Fatal("iterator key and value do not match", it.Key(), it.Value())
+		}
+		total += it.Value()
+	}
+
+	if total != 4 || ms.Keys().Length() != 2 || ms.Values().Length() != 2 {
+		t.Fatal("map iteration is incorrect", total)
+	}
+}
+
+func TestAdapters(t *testing.T) {
+	raw := []interface{}{1, 32, 56, 7}
+	typed := FromIterable[int, int](sequence.NewListIterator(raw))
+
+	sum := 0
+	for typed.Next() == nil {
+		sum += typed.Value()
+	}
+
+	if sum != 96 {
+		t.Fatal("typed iterator values are incorrect", sum)
+	}
+
+	bad := FromIterable[int, string](sequence.NewListIterator(raw))
+
+	if err := bad.Next(); err != sequence.ErrBADValue {
+		t.Fatal("mismatched value type should return ErrBADValue", err)
+	}
+
+	untyped := ToIterable[int, int](NewListIterator([]int{4, 5}))
+	total, err := sequence.Fold(untyped, 0, func(acc interface{}, f sequence.Iterable) (interface{}, error) {
+		return acc.(int) + f.Value().(int), nil
+	})
+
+	if err != nil || total != 9 {
+		t.Fatal("untyped iterator should work with sequence reducers", total, err)
+	}
+
+	ls, err := FromListSequence[int](sequence.NewListSequence(raw, 0), 0)
+
+	if err != nil || ls.Length() != 4 {
+		t.Fatal("list sequence conversion failed", err)
+	}
+
+	if back := ToListSequence(ls); back.Get(1) != 32 {
+		t.Fatal("list sequence round trip failed", back.Get(1))
+	}
+
+	ms, err := FromMapSequence[int, string](sequence.NewMapSequence(map[interface{}]interface{}{1: "a", 2: "b"}, 0), 0)
+
+	if err != nil || ms.Length() != 2 {
+		t.Fatal("map sequence conversion failed", err)
+	}
+
+	if back := ToMapSequence(ms); back.Get(2) != "b" {
+		t.Fatal("map sequence round trip failed", back.Get(2))
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/influx6/sequence
+
+go 1.24
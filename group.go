@@ -0,0 +1,185 @@
+package sequence
+
+import "errors"
+
+//GroupBy consumes the iterable, collecting its values into ListSequences
+//stored in a MapSequence under the key the function gives each item. The
+//groups are ordered by the first time their key was seen. A key that can not
+//be a map key stops it with ErrKeyType
+func GroupBy(it Iterable, fn KeyFunc) (MapSequencable, error) {
+	groups := NewOrderedMapSequence(nil, 0)
+
+	err := each(it, func(f Iterable) (bool, error) {
+		key := fn(f)
+
+		if !keyable(key) {
+			return false, ErrKeyType
+		}
+
+		group, ok := groups.Get(key).(ListSequencable)
+
+		if !ok {
+			group = NewListSequence(nil, 0)
+			groups.Add(key, group)
+		}
+
+		group.Add(f.Value())
+		return true, nil
+	})
+
+	return groups, err
+}
+
+//CountBy consumes the iterable, counting the items that share the key the
+//function gives them, ordered by the first time each key was seen. A key
+//that can not be a map key stops it with ErrKeyType
+func CountBy(it Iterable, fn KeyFunc) (MapSequencable, error) {
+	counts := NewOrderedMapSequence(nil, 0)
+
+	err := each(it, func(f Iterable) (bool, error) {
+		key := fn(f)
+
+		if !keyable(key) {
+			return false, ErrKeyType
+		}
+
+		n, _ := counts.Get(key).(int)
+		counts.Add(key, n+1)
+		return true, nil
+	})
+
+	return counts, err
+}
+
+//Partition consumes the iterable, splitting its values into those that pass
+//the predicate and those that fail it
+func Partition(it Iterable, fn PredFunc) (ListSequencable, ListSequencable, error) {
+	pass := NewListSequence(nil, 0)
+	fail := NewListSequence(nil, 0)
+
+	err := each(it, func(f Iterable) (bool, error) {
+		if fn(f) {
+			pass.Add(f.Value())
+		} else {
+			fail.Add(f.Value())
+		}
+		return true, nil
+	})
+
+	return pass, fail, err
+}
+
+//RunIterator lazily groups consecutive items of its parent sharing a key
+type RunIterator struct {
+	parent  Iterable
+	keyFn   KeyFunc
+	held    interface{}
+	heldKey interface{}
+	hold    bool
+	value   *ListSequence
+	key     interface{}
+	done    bool
+}
+
+//GroupRuns returns an iterator whoes values are ListSequences of consecutive
+//items the function gives the same key, keyed by that key. Unlike GroupBy a
+//key shows up again each time a new run of it starts. A key that can not be
+//compared makes Next return ErrKeyType
+func GroupRuns(it Iterable, fn KeyFunc) *RunIterator {
+	return &RunIterator{
+		parent: it.Clone(),
+		keyFn:  fn,
+	}
+}
+
+//Next moves to the next run
+func (r *RunIterator) Next() error {
+	var items []interface{}
+	var key interface{}
+
+	if r.hold {
+		items = append(items, r.held)
+		key = r.heldKey
+		r.held = nil
+		r.heldKey = nil
+		r.hold = false
+	}
+
+	for !r.done {
+		err := r.parent.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			r.done = true
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		k := r.keyFn(r.parent)
+
+		if !keyable(k) {
+			return ErrKeyType
+		}
+
+		if len(items) > 0 && k != key {
+			r.held = r.parent.Value()
+			r.heldKey = k
+			r.hold = true
+			break
+		}
+
+		key = k
+		items = append(items, r.parent.Value())
+	}
+
+	if len(items) == 0 {
+		r.value = nil
+		r.key = nil
+		return ErrENDINDEX
+	}
+
+	r.value = NewListSequence(items, 0)
+	r.key = key
+	return nil
+}
+
+//Reset reverst the iterators index
+func (r *RunIterator) Reset() {
+	r.parent.Reset()
+	r.held = nil
+	r.heldKey = nil
+	r.hold = false
+	r.value = nil
+	r.key = nil
+	r.done = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (r *RunIterator) Stop() {
+	stop(r.parent)
+}
+
+//Key returns the key shared by the current run
+func (r *RunIterator) Key() interface{} {
+	return r.key
+}
+
+//Value returns the current run as a ListSequence
+func (r *RunIterator) Value() interface{} {
+	if r.value == nil {
+		return nil
+	}
+	return r.value
+}
+
+//Length returns UNKNOWNLENGTH as the runs are only known after iteration
+func (r *RunIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (r *RunIterator) Clone() Iterable {
+	return GroupRuns(r.parent, r.keyFn)
+}
@@ -0,0 +1,105 @@
+package sequence
+
+import "testing"
+
+func parity(f Iterable) interface{} {
+	v, _ := f.Value().(int)
+	if v%2 == 0 {
+		return "even"
+	}
+	return "odd"
+}
+
+func TestGroupBy(t *testing.T) {
+	items := []interface{}{1, 2, 3, 4, 5}
+	groups, err := GroupBy(NewListIterator(items), parity)
+
+	if err != nil || groups.Length() != 2 {
+		t.Fatal("group count is incorrect", groups.Length(), err)
+	}
+
+	odd, _ := groups.Get("odd").(ListSequencable)
+
+	if odd == nil || !sameValues(odd.Obj(), []interface{}{1, 3, 5}) {
+		t.Fatal("odd group is incorrect", odd)
+	}
+
+	even, _ := groups.Get("even").(ListSequencable)
+
+	if even == nil || !sameValues(even.Obj(), []interface{}{2, 4}) {
+		t.Fatal("even group is incorrect", even)
+	}
+}
+
+func TestCountByAndPartition(t *testing.T) {
+	items := []interface{}{1, 2, 3, 4, 5}
+	counts, err := CountBy(NewListIterator(items), parity)
+
+	if err != nil || counts.Get("odd") != 3 || counts.Get("even") != 2 {
+		t.Fatal("counts are incorrect", counts.Obj(), err)
+	}
+
+	pass, fail, err := Partition(NewListIterator(items), even)
+
+	if err != nil {
+		t.Fatal("partition failed", err)
+	}
+
+	if !sameValues(pass.Obj(), []interface{}{2, 4}) || !sameValues(fail.Obj(), []interface{}{1, 3, 5}) {
+		t.Fatal("partitions are incorrect", pass.Obj(), fail.Obj())
+	}
+
+	if _, _, err = Partition(Take(counter(), 3), even); err != nil {
+		t.Fatal("partition over a limited generator failed", err)
+	}
+}
+
+func TestGroupRuns(t *testing.T) {
+	items := []interface{}{1, 3, 2, 4, 6, 5}
+	runs := GroupRuns(NewListIterator(items), parity)
+
+	var keys []interface{}
+	var groups [][]interface{}
+
+	for runs.Next() == nil {
+		keys = append(keys, runs.Key())
+		groups = append(groups, runs.Value().(*ListSequence).Obj())
+	}
+
+	if !sameValues(keys, []interface{}{"odd", "even", "odd"}) {
+		t.Fatal("run keys are incorrect", keys)
+	}
+
+	if !sameLists(groups, [][]interface{}{{1, 3}, {2, 4, 6}, {5}}) {
+		t.Fatal("runs are incorrect", groups)
+	}
+
+	lazy := Take(GroupRuns(counter(), func(f Iterable) interface{} {
+		v, _ := f.Value().(int)
+		return v / 3
+	}), 2)
+
+	if res := lists(lazy); !sameLists(res, [][]interface{}{{0, 1, 2}, {3, 4, 5}}) {
+		t.Fatal("runs over a generator are incorrect", res)
+	}
+}
+
+func TestGroupUnhashableKey(t *testing.T) {
+	slice := func(f Iterable) interface{} {
+		return []interface{}{f.Value()}
+	}
+
+	items := NewListIterator([]interface{}{1, 2})
+
+	if _, err := GroupBy(items, slice); err != ErrKeyType {
+		t.Fatal("group by an unhashable key should return ErrKeyType", err)
+	}
+
+	if _, err := CountBy(items, slice); err != ErrKeyType {
+		t.Fatal("count by an unhashable key should return ErrKeyType", err)
+	}
+
+	if err := GroupRuns(items, slice).Next(); err != ErrKeyType {
+		t.Fatal("runs of an unhashable key should return ErrKeyType", err)
+	}
+}
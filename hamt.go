@@ -0,0 +1,376 @@
+package sequence
+
+import "hash/maphash"
+import "math/bits"
+
+//hamtSeed is shared by every PersistentMap so versions hash keys alike
+var hamtSeed = maphash.MakeSeed()
+
+//hamtEntry is either a key value pair or a child node
+type hamtEntry struct {
+	hash  uint64
+	key   interface{}
+	value interface{}
+	child *hamtNode
+}
+
+//hamtNode holds the entries whose bits are set in the bitmap in bit order.
+//Past the last level of the hash it is a bucket of colliding keys instead
+type hamtNode struct {
+	bitmap  uint32
+	entries []hamtEntry
+}
+
+var emptyHamtNode = &hamtNode{}
+
+//hashKey hashes a key, it panics on keys that are not comparable as maps do.
+//maphash.Comparable is why the module needs go 1.24
+func hashKey(k interface{}) uint64 {
+	return maphash.Comparable(hamtSeed, k)
+}
+
+//spot returns the bit for the hash at the level and its entry position
+func (n *hamtNode) spot(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & vecMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+//with returns a copy of the node with the entry at pos replaced, inserted or
+//removed
+func (n *hamtNode) with(bitmap uint32, pos int, e *hamtEntry, insert bool) *hamtNode {
+	var entries []hamtEntry
+
+	switch {
+	case e == nil:
+		entries = make([]hamtEntry, 0, len(n.entries)-1)
+		entries = append(entries, n.entries[:pos]...)
+		entries = append(entries, n.entries[pos+1:]...)
+	case insert:
+		entries = make([]hamtEntry, 0, len(n.entries)+1)
+		entries = append(entries, n.entries[:pos]...)
+		entries = append(entries, *e)
+		entries = append(entries, n.entries[pos:]...)
+	default:
+		entries = make([]hamtEntry, len(n.entries))
+		copy(entries, n.entries)
+		entries[pos] = *e
+	}
+
+	return &hamtNode{bitmap, entries}
+}
+
+//find looks the key up below the node
+func (n *hamtNode) find(shift uint, hash uint64, k interface{}) (interface{}, bool) {
+	for {
+		if shift >= 64 {
+			for _, e := range n.entries {
+				if e.key == k {
+					return e.value, true
+				}
+			}
+			return nil, false
+		}
+
+		bit, pos := n.spot(hash, shift)
+
+		if n.bitmap&bit == 0 {
+			return nil, false
+		}
+
+		e := n.entries[pos]
+
+		if e.child == nil {
+			if e.hash == hash && e.key == k {
+				return e.value, true
+			}
+			return nil, false
+		}
+
+		n = e.child
+		shift += vecBits
+	}
+}
+
+//assoc returns a copy of the path to the key with its value set, reporting
+//if the key is new
+func (n *hamtNode) assoc(shift uint, hash uint64, k, v interface{}) (*hamtNode, bool) {
+	entry := &hamtEntry{hash, k, v, nil}
+
+	if shift >= 64 {
+		for i, e := range n.entries {
+			if e.key == k {
+				return n.with(n.bitmap, i, entry, false), false
+			}
+		}
+		return n.with(n.bitmap, len(n.entries), entry, true), true
+	}
+
+	bit, pos := n.spot(hash, shift)
+
+	if n.bitmap&bit == 0 {
+		return n.with(n.bitmap|bit, pos, entry, true), true
+	}
+
+	e := n.entries[pos]
+
+	if e.child != nil {
+		child, added := e.child.assoc(shift+vecBits, hash, k, v)
+		return n.with(n.bitmap, pos, &hamtEntry{child: child}, false), added
+	}
+
+	if e.hash == hash && e.key == k {
+		return n.with(n.bitmap, pos, entry, false), false
+	}
+
+	child, _ := emptyHamtNode.assoc(shift+vecBits, e.hash, e.key, e.value)
+	child, _ = child.assoc(shift+vecBits, hash, k, v)
+	return n.with(n.bitmap, pos, &hamtEntry{child: child}, false), true
+}
+
+//without returns a copy of the path to the key with it removed, reporting if
+//the key was found. Children left with a single pair are pulled up
+func (n *hamtNode) without(shift uint, hash uint64, k interface{}) (*hamtNode, bool) {
+	if shift >= 64 {
+		for i, e := range n.entries {
+			if e.key == k {
+				return n.with(n.bitmap, i, nil, false), true
+			}
+		}
+		return n, false
+	}
+
+	bit, pos := n.spot(hash, shift)
+
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	e := n.entries[pos]
+
+	if e.child == nil {
+		if e.hash != hash || e.key != k {
+			return n, false
+		}
+		return n.with(n.bitmap&^bit, pos, nil, false), true
+	}
+
+	child, removed := e.child.without(shift+vecBits, hash, k)
+
+	if !removed {
+		return n, false
+	}
+
+	switch {
+	case len(child.entries) == 0:
+		return n.with(n.bitmap&^bit, pos, nil, false), true
+	case len(child.entries) == 1 && child.entries[0].child == nil:
+		return n.with(n.bitmap, pos, &child.entries[0], false), true
+	}
+
+	return n.with(n.bitmap, pos, &hamtEntry{child: child}, false), true
+}
+
+//PersistentMap represents an immutable map on a hash array mapped trie,
+//every change returns a new version sharing all untouched nodes with the old
+//one. Versions are never written to, so they need no locking
+type PersistentMap struct {
+	root  *hamtNode
+	count int
+}
+
+var emptyMap = &PersistentMap{emptyHamtNode, 0}
+
+//NewPersistentMap returns a new PersistentMap holding data
+func NewPersistentMap(data map[interface{}]interface{}) *PersistentMap {
+	p := emptyMap
+
+	for k, v := range data {
+		p = p.Assoc(k, v)
+	}
+
+	return p
+}
+
+//Find returns the value of the key and reports if it was found
+func (p *PersistentMap) Find(k interface{}) (interface{}, bool) {
+	return p.root.find(0, hashKey(k), k)
+}
+
+//Assoc returns a new version with the key set to v
+func (p *PersistentMap) Assoc(k, v interface{}) *PersistentMap {
+	root, added := p.root.assoc(0, hashKey(k), k, v)
+
+	if added {
+		return &PersistentMap{root, p.count + 1}
+	}
+
+	return &PersistentMap{root, p.count}
+}
+
+//Dissoc returns a new version without the key, or the same version if the
+//key is not in it
+func (p *PersistentMap) Dissoc(k interface{}) *PersistentMap {
+	root, removed := p.root.without(0, hashKey(k), k)
+
+	if !removed {
+		return p
+	}
+
+	return &PersistentMap{root, p.count - 1}
+}
+
+//Add returns a new version with the key and value pairs set, a trailing key
+//without a value is ignored
+func (p *PersistentMap) Add(f ...interface{}) ImmutableMapSequencable {
+	n := p
+
+	for i := 0; i+1 < len(f); i += 2 {
+		n = n.Assoc(f[i], f[i+1])
+	}
+
+	return n
+}
+
+//Delete returns a new version without the keys
+func (p *PersistentMap) Delete(f ...interface{}) ImmutableMapSequencable {
+	n := p
+
+	for _, k := range f {
+		n = n.Dissoc(k)
+	}
+
+	return n
+}
+
+//Get retrieves the value
+func (p *PersistentMap) Get(k interface{}) interface{} {
+	v, _ := p.Find(k)
+	return v
+}
+
+//Length returns length of data
+func (p *PersistentMap) Length() int {
+	return p.count
+}
+
+//Obj returns a copy of the data as a map
+func (p *PersistentMap) Obj() map[interface{}]interface{} {
+	m := make(map[interface{}]interface{}, p.count)
+	it := p.Iterator()
+
+	for it.Next() == nil {
+		m[it.Key()] = it.Value()
+	}
+
+	return m
+}
+
+//Clear returns the empty version
+func (p *PersistentMap) Clear() ImmutableMapSequencable {
+	return emptyMap
+}
+
+//Iterator returns an iterator walking this version in hash order
+func (p *PersistentMap) Iterator() Iterable {
+	it := &PersistentMapIterator{p, nil, nil, nil}
+	it.Reset()
+	return it
+}
+
+//Parent returns the sequence as a sequencable
+func (p *PersistentMap) Parent() Sequencable {
+	return Sequencable(p)
+}
+
+//Values returns the values as a ListSequence
+func (p *PersistentMap) Values() ListSequencable {
+	kl := NewListSequence(nil, 0)
+	it := p.Iterator()
+
+	for it.Next() == nil {
+		kl.Add(it.Value())
+	}
+
+	return kl
+}
+
+//Keys returns the keys as a ListSequence
+func (p *PersistentMap) Keys() ListSequencable {
+	kl := NewListSequence(nil, 0)
+	it := p.Iterator()
+
+	for it.Next() == nil {
+		kl.Add(it.Key())
+	}
+
+	return kl
+}
+
+//hamtFrame is a node being walked and the next entry to visit
+type hamtFrame struct {
+	node *hamtNode
+	pos  int
+}
+
+//PersistentMapIterator walks a PersistentMap depth first
+type PersistentMapIterator struct {
+	pmap  *PersistentMap
+	stack []hamtFrame
+	key   interface{}
+	value interface{}
+}
+
+//Next moves to the next pair
+func (p *PersistentMapIterator) Next() error {
+	for len(p.stack) > 0 {
+		top := &p.stack[len(p.stack)-1]
+
+		if top.pos >= len(top.node.entries) {
+			p.stack = p.stack[:len(p.stack)-1]
+			continue
+		}
+
+		e := top.node.entries[top.pos]
+		top.pos++
+
+		if e.child != nil {
+			p.stack = append(p.stack, hamtFrame{e.child, 0})
+			continue
+		}
+
+		p.key = e.key
+		p.value = e.value
+		return nil
+	}
+
+	p.key = nil
+	p.value = nil
+	return ErrENDINDEX
+}
+
+//Reset reverst the iterators index
+func (p *PersistentMapIterator) Reset() {
+	p.stack = append(p.stack[:0], hamtFrame{p.pmap.root, 0})
+	p.key = nil
+	p.value = nil
+}
+
+//Key returns the current key
+func (p *PersistentMapIterator) Key() interface{} {
+	return p.key
+}
+
+//Value returns the current value
+func (p *PersistentMapIterator) Value() interface{} {
+	return p.value
+}
+
+//Length returns the size of the map
+func (p *PersistentMapIterator) Length() int {
+	return p.pmap.count
+}
+
+//Clone returns a new iterator off that data
+func (p *PersistentMapIterator) Clone() Iterable {
+	return p.pmap.Iterator()
+}
@@ -0,0 +1,100 @@
+package sequence
+
+import "testing"
+
+func TestPersistentMap(t *testing.T) {
+	var v1 ImmutableMapSequencable = NewPersistentMap(map[interface{}]interface{}{"a": 1, "b": 2})
+
+	v2 := v1.Add("c", 3, "a", 10)
+	v3 := v2.Delete("b", "missing")
+
+	if v1.Get("a") != 1 || v1.Length() != 2 || v1.Get("c") != nil {
+		t.Fatal("add should leave the old version untouched", v1.Obj())
+	}
+
+	if v2.Get("a") != 10 || v2.Length() != 3 {
+		t.Fatal("add should set new and existing keys", v2.Obj())
+	}
+
+	if v3.Get("b") != nil || v3.Length() != 2 || v2.Get("b") != 2 {
+		t.Fatal("delete should leave the old version untouched", v3.Obj())
+	}
+
+	obj := v3.Obj()
+
+	if len(obj) != 2 || obj["a"] != 10 || obj["c"] != 3 {
+		t.Fatal("obj is incorrect", obj)
+	}
+
+	if n := len(collect(v3.Iterator())); n != 2 || v3.Keys().Length() != 2 {
+		t.Fatal("iterator should walk every pair", n)
+	}
+
+	if v3.Clear().Length() != 0 {
+		t.Fatal("clear should return an empty version")
+	}
+}
+
+func TestPersistentMapLarge(t *testing.T) {
+	const size = 20000
+
+	p := emptyMap
+
+	for i := 0; i < size; i++ {
+		p = p.Assoc(i, i*2)
+	}
+
+	half := p
+
+	for i := 0; i < size; i += 2 {
+		p = p.Dissoc(i)
+	}
+
+	if p.Length() != size/2 || half.Length() != size {
+		t.Fatal("versions have the wrong size", p.Length(), half.Length())
+	}
+
+	for i := 0; i < size; i++ {
+		v, ok := p.Find(i)
+
+		if ok != (i%2 == 1) || (ok && v != i*2) {
+			t.Fatal("find is incorrect", i, v, ok)
+		}
+
+		if half.Get(i) != i*2 {
+			t.Fatal("older version lost a key", i)
+		}
+	}
+
+	if n := len(collect(p.Iterator())); n != size/2 {
+		t.Fatal("iterator count is incorrect", n)
+	}
+}
+
+func TestHamtCollisions(t *testing.T) {
+	n, _ := emptyHamtNode.assoc(0, 42, "a", 1)
+	n, _ = n.assoc(0, 42, "b", 2)
+	n, added := n.assoc(0, 42, "b", 3)
+
+	if added {
+		t.Fatal("replacing a colliding key should not add it")
+	}
+
+	if v, ok := n.find(0, 42, "a"); !ok || v != 1 {
+		t.Fatal("colliding keys should both be found", v)
+	}
+
+	if v, _ := n.find(0, 42, "b"); v != 3 {
+		t.Fatal("colliding key should be replaced", v)
+	}
+
+	n, removed := n.without(0, 42, "a")
+
+	if !removed || len(n.entries) != 1 || n.entries[0].child != nil {
+		t.Fatal("removing a collision should pull the last key up")
+	}
+
+	if v, ok := n.find(0, 42, "b"); !ok || v != 3 {
+		t.Fatal("remaining key should still be found", v)
+	}
+}
@@ -0,0 +1,262 @@
+package sequence
+
+import "container/heap"
+
+//heapData implements heap.Interface over the items of a HeapSequence
+type heapData struct {
+	items []interface{}
+	less  LessFunc
+}
+
+func (h *heapData) Len() int {
+	return len(h.items)
+}
+
+func (h *heapData) Less(i, j int) bool {
+	return h.less(h.items[i], h.items[j])
+}
+
+func (h *heapData) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *heapData) Push(v interface{}) {
+	h.items = append(h.items, v)
+}
+
+func (h *heapData) Pop() interface{} {
+	last := len(h.items) - 1
+	v := h.items[last]
+	h.items[last] = nil
+	h.items = h.items[:last]
+	return v
+}
+
+//clone returns a copy of the heap sharing no items storage
+func (h *heapData) clone() *heapData {
+	items := make([]interface{}, len(h.items))
+	copy(items, h.items)
+	return &heapData{items, h.less}
+}
+
+//HeapSequence represents a priority queue that always gives back the item
+//ordered first by its LessFunc
+type HeapSequence struct {
+	*Sequence
+	data   *heapData
+	buffer int
+}
+
+//NewHeapSequence returns a new HeapSequence holding data ordered by less
+func NewHeapSequence(data []interface{}, buff int, less LessFunc) *HeapSequence {
+	items := make([]interface{}, len(data))
+	copy(items, data)
+
+	h := &HeapSequence{
+		NewBaseSequence(buff, nil),
+		&heapData{items, less},
+		buff,
+	}
+
+	heap.Init(h.data)
+	return h
+}
+
+//Push adds the items to the heap
+func (h *HeapSequence) Push(f ...interface{}) *HeapSequence {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, v := range f {
+		heap.Push(h.data, v)
+	}
+
+	return h
+}
+
+//Pop removes and returns the first item in priority order or ErrEmpty
+func (h *HeapSequence) Pop() (interface{}, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.data.Len() == 0 {
+		return nil, ErrEmpty
+	}
+
+	return heap.Pop(h.data), nil
+}
+
+//Peek returns the first item in priority order without removing it or
+//ErrEmpty
+func (h *HeapSequence) Peek() (interface{}, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if h.data.Len() == 0 {
+		return nil, ErrEmpty
+	}
+
+	return h.data.items[0], nil
+}
+
+//Fix replaces the item at position i of the heap, as given by Obj, and
+//restores the heap order. It returns ErrBADINDEX if i is out of range
+func (h *HeapSequence) Fix(i int, v interface{}) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if i < 0 || i >= h.data.Len() {
+		return ErrBADINDEX
+	}
+
+	h.data.items[i] = v
+	heap.Fix(h.data, i)
+	return nil
+}
+
+//Length returns length of data
+func (h *HeapSequence) Length() int {
+	h.lock.RLock()
+	sz := h.data.Len()
+	h.lock.RUnlock()
+	return sz
+}
+
+//Obj returns a copy of the items in heap layout, the first being the next to
+//be popped
+func (h *HeapSequence) Obj() []interface{} {
+	h.lock.RLock()
+	items := h.data.clone().items
+	h.lock.RUnlock()
+	return items
+}
+
+//Mutate allows mutation on a copy of the items, the returned list is heaped
+//again to become the new content
+func (h *HeapSequence) Mutate(fn MutFunc) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	res, ok := fn(h.data.clone().items).([]interface{})
+
+	if !ok {
+		return
+	}
+
+	h.data.items = res
+	heap.Init(h.data)
+}
+
+//Iterator returns an iterator walking a snapshot of the heap in priority
+//order without consuming it
+func (h *HeapSequence) Iterator() Iterable {
+	h.lock.RLock()
+	data := h.data.clone()
+	h.lock.RUnlock()
+	return &HeapIterator{nil, data, data.clone(), nil, -1}
+}
+
+//DrainIterator returns an iterator that pops from the heap on every Next, it
+//ends once the heap is empty
+func (h *HeapSequence) DrainIterator() Iterable {
+	return &HeapIterator{h, nil, nil, nil, -1}
+}
+
+//Parent returns the sequence as a sequencable
+func (h *HeapSequence) Parent() Sequencable {
+	return Sequencable(h)
+}
+
+//Clear wipes internal structure data
+func (h *HeapSequence) Clear() *HeapSequence {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.data.items = make([]interface{}, 0)
+	return h
+}
+
+//Clone copies internal structure data
+func (h *HeapSequence) Clone() *HeapSequence {
+	h.lock.RLock()
+	data := h.data.clone()
+	h.lock.RUnlock()
+	return &HeapSequence{NewBaseSequence(h.buffer, nil), data, h.buffer}
+}
+
+//HeapIterator yields the items of a heap in priority order keyed by position,
+//either popping from a live HeapSequence or from a private snapshot
+type HeapIterator struct {
+	live  *HeapSequence
+	snap  *heapData
+	data  *heapData
+	value interface{}
+	index int
+}
+
+//Next moves to the next item in priority order
+func (h *HeapIterator) Next() error {
+	var v interface{}
+
+	if h.live != nil {
+		item, err := h.live.Pop()
+
+		if err != nil {
+			h.value = nil
+			return ErrENDINDEX
+		}
+
+		v = item
+	} else {
+		if h.data.Len() == 0 {
+			h.value = nil
+			return ErrENDINDEX
+		}
+
+		v = heap.Pop(h.data)
+	}
+
+	h.value = v
+	h.index++
+	return nil
+}
+
+//Reset restarts a snapshot walk from the first item, a draining iterator
+//only restarts its positions as popped items are gone
+func (h *HeapIterator) Reset() {
+	if h.snap != nil {
+		h.data = h.snap.clone()
+	}
+	h.value = nil
+	h.index = -1
+}
+
+//Key returns the current position in priority order
+func (h *HeapIterator) Key() interface{} {
+	if h.index < 0 {
+		return nil
+	}
+	return h.index
+}
+
+//Value returns the current item
+func (h *HeapIterator) Value() interface{} {
+	return h.value
+}
+
+//Length returns the size of the snapshot or UNKNOWNLENGTH when draining, as
+//producers may push while it runs
+func (h *HeapIterator) Length() int {
+	if h.live != nil {
+		return UNKNOWNLENGTH
+	}
+	return h.snap.Len()
+}
+
+//Clone returns a new iterator off that data
+func (h *HeapIterator) Clone() Iterable {
+	if h.live != nil {
+		return &HeapIterator{h.live, nil, nil, nil, -1}
+	}
+	return &HeapIterator{nil, h.snap, h.snap.clone(), nil, -1}
+}
@@ -0,0 +1,117 @@
+package sequence
+
+import "sync"
+import "testing"
+
+func TestHeapSequence(t *testing.T) {
+	var seq MutableSizableSequencable = NewHeapSequence([]interface{}{5, 3, 9}, 0, intLess)
+	h := seq.(*HeapSequence)
+
+	h.Push(1, 7)
+
+	if v, err := h.Peek(); err != nil || v != 1 || h.Length() != 5 {
+		t.Fatal("peek should give the smallest item", v, err)
+	}
+
+	if res := collect(h.Iterator()); !sameValues(res, []interface{}{1, 3, 5, 7, 9}) {
+		t.Fatal("iterator should walk in priority order", res)
+	}
+
+	if h.Length() != 5 {
+		t.Fatal("iterator should not consume the heap", h.Length())
+	}
+
+	if err := h.Fix(0, 8); err != nil {
+		t.Fatal("fix should accept a valid position", err)
+	}
+
+	if err := h.Fix(5, 0); err != ErrBADINDEX {
+		t.Fatal("fix should reject a bad position", err)
+	}
+
+	if v, err := h.Pop(); err != nil || v != 3 {
+		t.Fatal("pop should give the smallest item after a fix", v, err)
+	}
+
+	cl := h.Clone()
+	cl.Push(0)
+
+	if v, _ := h.Peek(); v != 5 {
+		t.Fatal("clone should not share data with its source", v)
+	}
+
+	if res := collect(h.DrainIterator()); !sameValues(res, []interface{}{5, 7, 8, 9}) {
+		t.Fatal("drain iterator should pop in priority order", res)
+	}
+
+	if _, err := h.Pop(); err != ErrEmpty || h.Length() != 0 {
+		t.Fatal("drained heap should be empty", err)
+	}
+
+	if _, err := h.Peek(); err != ErrEmpty {
+		t.Fatal("peek on an empty heap should return ErrEmpty", err)
+	}
+}
+
+func TestHeapPushPanicReleasesLock(t *testing.T) {
+	h := NewHeapSequence([]interface{}{3, 1}, 0, func(a, b interface{}) bool {
+		return a.(int) < b.(int)
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("pushing a string onto an int heap should panic")
+			}
+		}()
+		h.Push("x")
+	}()
+
+	released(t, h.Sequence)
+}
+
+func TestHeapIteratorReset(t *testing.T) {
+	h := NewHeapSequence([]interface{}{4, 2, 6}, 0, intLess)
+	it := h.Iterator()
+
+	collect(it)
+	it.Reset()
+
+	if res := collect(it); !sameValues(res, []interface{}{2, 4, 6}) || it.Length() != 3 {
+		t.Fatal("reset iterator should walk the snapshot again", res)
+	}
+
+	h.Push(1)
+
+	if res := collect(it.Clone()); !sameValues(res, []interface{}{2, 4, 6}) {
+		t.Fatal("clone should keep the snapshot", res)
+	}
+}
+
+func TestHeapConcurrentDrain(t *testing.T) {
+	h := NewHeapSequence(nil, 0, intLess)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				h.Push(i)
+			}
+		}()
+	}
+
+	popped := 0
+	for popped < 400 {
+		if _, err := h.Pop(); err == nil {
+			popped++
+		}
+	}
+
+	wg.Wait()
+
+	if h.Length() != 0 {
+		t.Fatal("every pushed item should be popped", h.Length())
+	}
+}
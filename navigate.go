@@ -0,0 +1,240 @@
+package sequence
+
+import "errors"
+
+//Peek returns the key and value of the next item without moving to it
+func (l *ListIterator) Peek() (interface{}, interface{}, error) {
+	if !l.hasNext() {
+		return nil, nil, ErrENDINDEX
+	}
+
+	return l.index + 1, l.data[l.index+1], nil
+}
+
+//SeekIndex makes the item at the position current, it returns ErrOutOfRange
+//and stays put if there is none
+func (l *ListIterator) SeekIndex(i int) error {
+	if i < 0 || i >= len(l.data) {
+		return ErrOutOfRange
+	}
+
+	l.index = i
+	return nil
+}
+
+//Seek makes the item at the index key current, it returns ErrKeyType if the
+//key is not an int and ErrOutOfRange if it is not in the list
+func (l *ListIterator) Seek(key interface{}) error {
+	i, ok := key.(int)
+
+	if !ok {
+		return ErrKeyType
+	}
+
+	return l.SeekIndex(i)
+}
+
+//Prev moves to the item before the current one
+func (l *ListIterator) Prev() error {
+	if l.index <= 0 {
+		l.index = -1
+		return ErrENDINDEX
+	}
+
+	l.index--
+	return nil
+}
+
+//Peek returns the key and value of the next item without moving to it
+func (r *ReverseListIterator) Peek() (interface{}, interface{}, error) {
+	if !r.hasNext() {
+		return nil, nil, ErrENDINDEX
+	}
+
+	k := (len(r.data) - 1) - (r.index + 1)
+	return k, r.data[k], nil
+}
+
+//Seek makes the item at the index key current, it returns ErrKeyType if the
+//key is not an int and ErrOutOfRange if it is not in the list
+func (r *ReverseListIterator) Seek(key interface{}) error {
+	i, ok := key.(int)
+
+	if !ok {
+		return ErrKeyType
+	}
+
+	return r.SeekIndex((len(r.data) - 1) - i)
+}
+
+//navigator returns the key iterator of the map as a navigable one, the map
+//iterator always builds it from a list iterator
+func (m *MapIterator) navigator() interface {
+	Peeker
+	Seeker
+	BidiIterable
+} {
+	if r, ok := m.Iterable.(*ReverseListIterator); ok {
+		return r
+	}
+	return m.Iterable.(*ListIterator)
+}
+
+//Peek returns the key and value of the next item without moving to it
+func (m *MapIterator) Peek() (interface{}, interface{}, error) {
+	_, k, err := m.navigator().Peek()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k, m.data[k], nil
+}
+
+//SeekIndex makes the item at the position of the walk current, it returns
+//ErrOutOfRange and stays put if there is none
+func (m *MapIterator) SeekIndex(i int) error {
+	return m.navigator().SeekIndex(i)
+}
+
+//Seek makes the item with the key current, it returns ErrNotFound and stays
+//put if the key is not in the walk
+func (m *MapIterator) Seek(key interface{}) error {
+	if !keyable(key) {
+		return ErrKeyType
+	}
+
+	for i, k := range m.keys {
+		if k != key {
+			continue
+		}
+
+		if m.reverse {
+			return m.SeekIndex((len(m.keys) - 1) - i)
+		}
+
+		return m.SeekIndex(i)
+	}
+
+	return ErrNotFound
+}
+
+//Prev moves to the item before the current one
+func (m *MapIterator) Prev() error {
+	return m.navigator().Prev()
+}
+
+//peeked is an item read ahead of the current one
+type peeked struct {
+	key   interface{}
+	value interface{}
+	err   error
+}
+
+//PeekableIterator adds look ahead to any iterable by buffering the items it
+//reads ahead of the current one
+type PeekableIterator struct {
+	parent Iterable
+	buffer []peeked
+	key    interface{}
+	value  interface{}
+}
+
+//Peekable returns an iterator over the iterable that can look ahead
+func Peekable(it Iterable) *PeekableIterator {
+	return &PeekableIterator{it.Clone(), nil, nil, nil}
+}
+
+//fill reads ahead until n items are buffered or the parent stops
+func (p *PeekableIterator) fill(n int) {
+	for len(p.buffer) < n {
+		if len(p.buffer) > 0 && p.buffer[len(p.buffer)-1].err != nil {
+			return
+		}
+
+		err := p.parent.Next()
+
+		if err != nil {
+			p.buffer = append(p.buffer, peeked{nil, nil, err})
+			return
+		}
+
+		p.buffer = append(p.buffer, peeked{p.parent.Key(), p.parent.Value(), nil})
+	}
+}
+
+//Peek returns the key and value of the next item without moving to it
+func (p *PeekableIterator) Peek() (interface{}, interface{}, error) {
+	return p.PeekN(1)
+}
+
+//PeekN returns the key and value of the item n steps ahead without moving,
+//PeekN(1) being the next item. It returns the error that stops the parent
+//first if it does not reach that far
+func (p *PeekableIterator) PeekN(n int) (interface{}, interface{}, error) {
+	if n < 1 {
+		return nil, nil, ErrOutOfRange
+	}
+
+	p.fill(n)
+
+	if len(p.buffer) < n {
+		return nil, nil, p.buffer[len(p.buffer)-1].err
+	}
+
+	item := p.buffer[n-1]
+	return item.key, item.value, item.err
+}
+
+//Next moves to the next item, taking it from the buffer when read ahead
+func (p *PeekableIterator) Next() error {
+	p.fill(1)
+
+	item := p.buffer[0]
+	p.buffer = p.buffer[1:]
+
+	if item.err != nil {
+		if errors.Is(item.err, ErrBADValue) {
+			p.key = nil
+			p.value = nil
+		}
+		return item.err
+	}
+
+	p.key = item.key
+	p.value = item.value
+	return nil
+}
+
+//Reset reverst the iterators index
+func (p *PeekableIterator) Reset() {
+	p.parent.Reset()
+	p.buffer = nil
+	p.key = nil
+	p.value = nil
+}
+
+//Stop releases the parent iterator if it holds resources
+func (p *PeekableIterator) Stop() {
+	stop(p.parent)
+}
+
+//Key returns the current key of the iterator
+func (p *PeekableIterator) Key() interface{} {
+	return p.key
+}
+
+//Value returns the current value of the iterator
+func (p *PeekableIterator) Value() interface{} {
+	return p.value
+}
+
+//Length returns the parent iterators targets length,not its operation length
+func (p *PeekableIterator) Length() int {
+	return p.parent.Length()
+}
+
+//Clone returns a new iterator off that data
+func (p *PeekableIterator) Clone() Iterable {
+	return Peekable(p.parent)
+}
@@ -0,0 +1,131 @@
+package sequence
+
+import "testing"
+
+func TestListIteratorNavigation(t *testing.T) {
+	var it Iterable = NewListIterator(data)
+
+	peeker := it.(Peeker)
+	seeker := it.(Seeker)
+	bidi := it.(BidiIterable)
+
+	if k, v, err := peeker.Peek(); err != nil || k != 0 || v != 1 || it.Key() != -1 {
+		t.Fatal("peek should look at the first item without moving", k, v, err)
+	}
+
+	if err := seeker.SeekIndex(2); err != nil || it.Value() != 56 {
+		t.Fatal("seek index is incorrect", it.Value(), err)
+	}
+
+	if err := seeker.Seek(9); err != ErrOutOfRange || it.Value() != 56 {
+		t.Fatal("seek out of range should stay put", err)
+	}
+
+	if err := seeker.Seek("a"); err != ErrKeyType {
+		t.Fatal("seek with a bad key should return ErrKeyType", err)
+	}
+
+	if err := bidi.Prev(); err != nil || it.Value() != 32 {
+		t.Fatal("prev should step back", it.Value(), err)
+	}
+
+	bidi.Prev()
+
+	if err := bidi.Prev(); err != ErrENDINDEX || it.Next() != nil || it.Value() != 1 {
+		t.Fatal("prev past the start should end and act as a reset", err)
+	}
+
+	seeker.SeekIndex(3)
+
+	if _, _, err := peeker.Peek(); err != ErrENDINDEX {
+		t.Fatal("peek at the end should return ErrENDINDEX", err)
+	}
+}
+
+func TestReverseListIteratorNavigation(t *testing.T) {
+	it := NewReverseListIterator(data)
+
+	if k, v, _ := it.Peek(); k != 3 || v != 7 {
+		t.Fatal("reverse peek is incorrect", k, v)
+	}
+
+	if err := it.Seek(1); err != nil || it.Key() != 1 || it.Value() != 32 {
+		t.Fatal("reverse seek by key is incorrect", it.Key(), it.Value(), err)
+	}
+
+	if k, v, _ := it.Peek(); k != 0 || v != 1 {
+		t.Fatal("reverse peek after seek is incorrect", k, v)
+	}
+
+	if err := it.Prev(); err != nil || it.Key() != 2 {
+		t.Fatal("reverse prev should step back towards the end", it.Key(), err)
+	}
+}
+
+func TestMapIteratorNavigation(t *testing.T) {
+	ms := NewSortedMapSequence(map[interface{}]interface{}{1: "a", 2: "b", 3: "c"}, 0, intLess)
+	it := ms.Iterator().(*MapIterator)
+
+	if k, v, _ := it.Peek(); k != 1 || v != "a" {
+		t.Fatal("map peek is incorrect", k, v)
+	}
+
+	if err := it.Seek(2); err != nil || it.Key() != 2 || it.Value() != "b" {
+		t.Fatal("map seek is incorrect", it.Key(), err)
+	}
+
+	if err := it.Seek(9); err != ErrNotFound || it.Key() != 2 {
+		t.Fatal("map seek of a missing key should stay put", err)
+	}
+
+	if err := it.Prev(); err != nil || it.Key() != 1 {
+		t.Fatal("map prev is incorrect", it.Key(), err)
+	}
+
+	rev := ms.ReverseIterator().(*MapIterator)
+
+	if err := rev.Seek(3); err != nil || rev.Next() != nil || rev.Key() != 2 {
+		t.Fatal("reverse map seek should continue backwards", rev.Key(), err)
+	}
+
+	if err := rev.SeekIndex(0); err != nil || rev.Key() != 3 {
+		t.Fatal("reverse map seek index is incorrect", rev.Key(), err)
+	}
+}
+
+func TestPeekable(t *testing.T) {
+	p := Peekable(Filter(counter(), even))
+
+	if k, v, err := p.PeekN(3); err != nil || v != 4 || k != 4 {
+		t.Fatal("peek ahead is incorrect", k, v, err)
+	}
+
+	if v, _, _ := p.Peek(); v != 0 || p.Value() != nil {
+		t.Fatal("peek should not move", v)
+	}
+
+	var res []interface{}
+	for i := 0; i < 4 && p.Next() == nil; i++ {
+		res = append(res, p.Value())
+	}
+
+	if !sameValues(res, []interface{}{0, 2, 4, 6}) {
+		t.Fatal("peekable should yield buffered items first", res)
+	}
+
+	short := Peekable(NewListIterator(data))
+
+	if _, _, err := short.PeekN(5); err != ErrENDINDEX {
+		t.Fatal("peeking past the end should return ErrENDINDEX", err)
+	}
+
+	if res := collect(short); !sameValues(res, data) {
+		t.Fatal("peeking past the end should keep the items", res)
+	}
+
+	short.Reset()
+
+	if short.Next() != nil || short.Value() != 1 {
+		t.Fatal("reset should drop the buffer", short.Value())
+	}
+}
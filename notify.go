@@ -0,0 +1,267 @@
+package sequence
+
+import "sync"
+
+import "sync/atomic"
+
+//ChangeOp names the kind of write a Change describes
+type ChangeOp int
+
+const (
+	//ChangeAdd is an item added at Key with New as its value, Old holds the
+	//value it replaced for map keys that already existed
+	ChangeAdd ChangeOp = iota
+	//ChangeDelete is the item at Key removed, Old holds its value
+	ChangeDelete
+	//ChangeClear is every item removed, Old holds the previous data
+	ChangeClear
+	//ChangeMutate is the data replaced as a whole by Mutate or a sort, Old
+	//and New hold the data before and after
+	ChangeMutate
+)
+
+//Change describes a single write made to a sequence
+type Change struct {
+	Op  ChangeOp
+	Key interface{}
+	Old interface{}
+	New interface{}
+}
+
+//subscriber is a registered change handler, async ones are fed from their
+//own queue by their own goroutine so delivery never waits on them
+type subscriber struct {
+	fn     func(Change)
+	mu     sync.Mutex
+	queue  []Change
+	signal chan struct{}
+	quit   chan struct{}
+}
+
+//notifier delivers the changes of a sequence to its subscribers in the order
+//the writes were made
+type notifier struct {
+	mu      sync.Mutex
+	subLock sync.Mutex
+	subs    []*subscriber
+	active  atomic.Int32
+	pending []Change
+	buffer  int
+}
+
+//newNotifier returns a notifier whoes async subscribers start with room for
+//buff queued changes
+func newNotifier(buff int) *notifier {
+	return &notifier{buffer: buff}
+}
+
+//subscribe registers the handler and returns the function removing it
+func (n *notifier) subscribe(fn func(Change), async bool) func() {
+	s := &subscriber{fn: fn}
+
+	if async {
+		s.queue = make([]Change, 0, n.buffer)
+		s.signal = make(chan struct{}, 1)
+		s.quit = make(chan struct{})
+		go s.run()
+	}
+
+	n.subLock.Lock()
+	n.subs = append(n.subs, s)
+	n.subLock.Unlock()
+	n.active.Add(1)
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			n.subLock.Lock()
+			for i, sub := range n.subs {
+				if sub == s {
+					n.subs = append(n.subs[:i:i], n.subs[i+1:]...)
+					break
+				}
+			}
+			n.subLock.Unlock()
+			n.active.Add(-1)
+
+			if s.quit != nil {
+				close(s.quit)
+			}
+		})
+	}
+}
+
+//run hands the queued changes of an async subscriber to its handler in order
+//until it is unsubscribed, changes still queued at that point are dropped
+func (s *subscriber) run() {
+	for {
+		select {
+		case <-s.signal:
+		case <-s.quit:
+			return
+		}
+
+		for {
+			s.mu.Lock()
+			queue := s.queue
+			s.queue = nil
+			s.mu.Unlock()
+
+			if len(queue) == 0 {
+				break
+			}
+
+			for _, c := range queue {
+				select {
+				case <-s.quit:
+					return
+				default:
+				}
+
+				s.fn(c)
+			}
+		}
+	}
+}
+
+//send delivers the change, async subscribers only queue it and wake their
+//goroutine so a slow or writing handler never holds up the writer
+func (s *subscriber) send(c Change) {
+	if s.signal == nil {
+		s.fn(c)
+		return
+	}
+
+	select {
+	case <-s.quit:
+		return
+	default:
+	}
+
+	s.mu.Lock()
+	s.queue = append(s.queue, c)
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+//deliver hands the changes to every subscriber in order
+func (n *notifier) deliver(changes []Change) {
+	n.subLock.Lock()
+	subs := n.subs
+	n.subLock.Unlock()
+
+	for _, c := range changes {
+		for _, s := range subs {
+			s.send(c)
+		}
+	}
+}
+
+//listening reports if any subscriber would receive changes
+func (s *Sequence) listening() bool {
+	return s.notes.active.Load() > 0
+}
+
+//record queues a change for delivery once the lock is released, the caller
+//must hold the write lock
+func (s *Sequence) record(c Change) {
+	if s.listening() {
+		s.notes.pending = append(s.notes.pending, c)
+	}
+}
+
+//unlockAndNotify releases the write lock and delivers the changes recorded
+//under it. The notify lock is taken before the write lock is released, so
+//changes of later writes can not overtake these
+func (s *Sequence) unlockAndNotify() {
+	changes := s.notes.pending
+
+	if len(changes) == 0 {
+		s.lock.Unlock()
+		return
+	}
+
+	s.notes.pending = nil
+	s.notes.mu.Lock()
+	s.lock.Unlock()
+	s.notes.deliver(changes)
+	s.notes.mu.Unlock()
+}
+
+//Subscribe calls fn with every change made to the sequence from now on, in
+//the order the writes were made. It runs on the writing goroutine after the
+//lock is released, so it may read the sequence but must not write to it; use
+//SubscribeAsync for that. The returned function removes the subscription
+func (l *ListSequence) Subscribe(fn func(Change)) (unsubscribe func()) {
+	return l.notes.subscribe(fn, false)
+}
+
+//SubscribeAsync calls fn with every change made to the sequence from now on
+//on its own goroutine, in the order the writes were made. Changes queue up
+//until fn takes them, so writers never wait on it and fn may write to the
+//sequence itself. The returned function removes the subscription and drops
+//any change not yet handed to fn
+func (l *ListSequence) SubscribeAsync(fn func(Change)) (unsubscribe func()) {
+	return l.notes.subscribe(fn, true)
+}
+
+//Subscribe calls fn with every change made to the sequence from now on, see
+//ListSequence.Subscribe
+func (l *MapSequence) Subscribe(fn func(Change)) (unsubscribe func()) {
+	return l.notes.subscribe(fn, false)
+}
+
+//SubscribeAsync calls fn with every change made to the sequence from now on
+//on its own goroutine, see ListSequence.SubscribeAsync
+func (l *MapSequence) SubscribeAsync(fn func(Change)) (unsubscribe func()) {
+	return l.notes.subscribe(fn, true)
+}
+
+//before returns the data ahead of a write replacing it as a whole. When
+//anyone listens it is marked shared, so the write copies it first and it
+//stays intact for the Change. The caller must hold the lock
+func (l *ListSequence) before() []interface{} {
+	if l.listening() {
+		l.shared.Store(true)
+	}
+	return l.data
+}
+
+//mutated records a ChangeMutate from the old data to the current data, which
+//is marked shared so later writes leave it intact for the Change. The caller
+//must hold the lock
+func (l *ListSequence) mutated(old []interface{}) {
+	if !l.listening() {
+		return
+	}
+
+	l.shared.Store(true)
+	l.record(Change{ChangeMutate, nil, old, l.data})
+}
+
+//before returns the data ahead of a write replacing it as a whole. When
+//anyone listens it is marked shared, so the write copies it first and it
+//stays intact for the Change. The caller must hold the lock
+func (l *MapSequence) before() map[interface{}]interface{} {
+	if l.listening() {
+		l.shared.Store(true)
+	}
+	return l.data
+}
+
+//mutated records a ChangeMutate from the old data to the current data, which
+//is marked shared so later writes leave it intact for the Change. The caller
+//must hold the lock
+func (l *MapSequence) mutated(old map[interface{}]interface{}) {
+	if !l.listening() {
+		return
+	}
+
+	l.shared.Store(true)
+	l.record(Change{ChangeMutate, nil, old, l.data})
+}
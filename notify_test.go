@@ -0,0 +1,167 @@
+package sequence
+
+import "sync"
+import "testing"
+import "time"
+
+func TestListSubscribe(t *testing.T) {
+	ls := NewListSequence([]interface{}{1}, 0)
+
+	var changes []Change
+	unsubscribe := ls.Subscribe(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	ls.Add(2, 3)
+	ls.Delete(0)
+	ls.Mutate(func(f interface{}) interface{} {
+		return append(f.([]interface{}), 4)
+	})
+	ls.Clear()
+
+	want := []Change{
+		{ChangeAdd, 1, nil, 2},
+		{ChangeAdd, 2, nil, 3},
+		{ChangeDelete, 0, 1, nil},
+	}
+
+	if len(changes) != 5 {
+		t.Fatal("every write should send changes", changes)
+	}
+
+	for i, c := range want {
+		if changes[i] != c {
+			t.Fatal("change is incorrect", i, changes[i])
+		}
+	}
+
+	mut := changes[3]
+
+	if mut.Op != ChangeMutate || !sameValues(mut.Old.([]interface{}), []interface{}{2, 3}) || !sameValues(mut.New.([]interface{}), []interface{}{2, 3, 4}) {
+		t.Fatal("mutate change is incorrect", mut)
+	}
+
+	if clr := changes[4]; clr.Op != ChangeClear || !sameValues(clr.Old.([]interface{}), []interface{}{2, 3, 4}) {
+		t.Fatal("clear change is incorrect", clr)
+	}
+
+	unsubscribe()
+	unsubscribe()
+	ls.Add(5)
+
+	if len(changes) != 5 {
+		t.Fatal("unsubscribed handler should get no changes", changes)
+	}
+}
+
+func TestMapSubscribe(t *testing.T) {
+	ms := NewMapSequence(map[interface{}]interface{}{"a": 1}, 0)
+
+	var changes []Change
+	ms.Subscribe(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	ms.Add("a", 10)
+	ms.Add("b", 2)
+	ms.Delete("a")
+	ms.QueueAdd("c", 3)
+	ms.Flush()
+
+	want := []Change{
+		{ChangeAdd, "a", 1, 10},
+		{ChangeAdd, "b", nil, 2},
+		{ChangeDelete, "a", 10, nil},
+		{ChangeAdd, "c", nil, 3},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatal("every write should send a change", changes)
+	}
+
+	for i, c := range want {
+		if changes[i] != c {
+			t.Fatal("change is incorrect", i, changes[i])
+		}
+	}
+}
+
+func TestSubscribeAsyncOrdering(t *testing.T) {
+	ls := NewListSequence(nil, 4)
+
+	var mu sync.Mutex
+	var got []interface{}
+	done := make(chan struct{})
+
+	ls.SubscribeAsync(func(c Change) {
+		mu.Lock()
+		got = append(got, c.New)
+		n := len(got)
+		mu.Unlock()
+
+		if n == 400 {
+			close(done)
+		}
+	})
+
+	var direct []interface{}
+	ls.Subscribe(func(c Change) {
+		direct = append(direct, c.New)
+	})
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				ls.Add(i)
+			}
+		}()
+	}
+
+	wg.Wait()
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if !sameValues(got, ls.Obj()) || !sameValues(direct, ls.Obj()) {
+		t.Fatal("changes should arrive in the order writes were made")
+	}
+}
+
+func TestSubscribeAsyncWriteBack(t *testing.T) {
+	ms := NewMapSequence(nil, 1)
+
+	seen := 0
+	done := make(chan struct{})
+
+	ms.SubscribeAsync(func(c Change) {
+		if c.Key == "seen" {
+			return
+		}
+
+		ms.Add("seen", c.Key)
+
+		if seen++; seen == 200 {
+			close(done)
+		}
+	})
+
+	go func() {
+		for i := 0; i < 200; i++ {
+			ms.Add(i, i)
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("an async subscriber writing back should not deadlock its writers")
+	}
+
+	if ms.Get("seen") != 199 {
+		t.Fatal("write back should see the last change", ms.Get("seen"))
+	}
+}
@@ -0,0 +1,207 @@
+package sequence
+
+import "sort"
+
+//KeyOrder is the type of a function returning the keys of a map in the order
+//they should be visited
+type KeyOrder func(map[interface{}]interface{}) []interface{}
+
+//pickOrder returns the first supplied KeyOrder or GrabKeys when there is none
+func pickOrder(order []KeyOrder) KeyOrder {
+	if len(order) > 0 && order[0] != nil {
+		return order[0]
+	}
+	return GrabKeys
+}
+
+//SortedKeys returns a KeyOrder visiting the keys of a map sorted by the less
+//function, which must give a total order for the walk to be repeatable
+func SortedKeys(less LessFunc) KeyOrder {
+	return func(m map[interface{}]interface{}) []interface{} {
+		keys := GrabKeys(m)
+
+		sort.SliceStable(keys, func(i, j int) bool {
+			return less(keys[i], keys[j])
+		})
+
+		return keys
+	}
+}
+
+//NewOrderedMapSequence returns a MapSequence whoes iterators, Keys and Values
+//follow the order keys were first added in. Keys of the initial data have no
+//insertion order and come first in Go map order
+func NewOrderedMapSequence(data map[interface{}]interface{}, buff int) *MapSequence {
+	ms := NewMapSequence(data, buff)
+	ms.keys = GrabKeys(ms.data)
+	ms.index()
+	ms.order = ms.insertionOrder
+	return ms
+}
+
+//NewSortedMapSequence returns a MapSequence whoes iterators, Keys and Values
+//visit its keys sorted by the less function
+func NewSortedMapSequence(data map[interface{}]interface{}, buff int, less LessFunc) *MapSequence {
+	ms := NewMapSequence(data, buff)
+	ms.order = SortedKeys(less)
+	return ms
+}
+
+//tracked reports if the sequence keeps its insertion order
+func (l *MapSequence) tracked() bool {
+	return l.keys != nil
+}
+
+//removedKey marks the slot of a removed key in the insertion order, removing
+//a key only marks its slot and the order is compacted once most slots are
+//marked, keeping deletes O(1)
+type removedKey struct{}
+
+//index records the slot of every key in the insertion order, the caller must
+//hold the lock
+func (l *MapSequence) index() {
+	l.slots = make(map[interface{}]int, len(l.keys))
+
+	for i, k := range l.keys {
+		l.slots[k] = i
+	}
+}
+
+//live returns the insertion order without the slots of removed keys, the
+//caller must hold the lock
+func (l *MapSequence) live() []interface{} {
+	keys := make([]interface{}, 0, len(l.slots))
+
+	for _, k := range l.keys {
+		if _, ok := k.(removedKey); !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+//insertionOrder is the KeyOrder of an ordered sequence, it returns the keys of
+//the map in insertion order with any untracked key at the end
+func (l *MapSequence) insertionOrder(m map[interface{}]interface{}) []interface{} {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.orderedKeys(m)
+}
+
+//orderedKeys returns the keys of the map in insertion order with any
+//untracked key at the end, the caller must hold the lock
+func (l *MapSequence) orderedKeys(m map[interface{}]interface{}) []interface{} {
+	keys := make([]interface{}, 0, len(m))
+	seen := make(map[interface{}]bool, len(m))
+
+	for _, k := range l.keys {
+		if _, ok := m[k]; ok {
+			keys = append(keys, k)
+			seen[k] = true
+		}
+	}
+
+	for k := range m {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+//set stores the value for the key, the caller must hold the lock
+func (l *MapSequence) set(key, val interface{}) {
+	l.own()
+
+	old, ok := l.data[key]
+
+	if !ok && l.tracked() {
+		l.slots[key] = len(l.keys)
+		l.keys = append(l.keys, key)
+	}
+
+	l.record(Change{ChangeAdd, key, old, val})
+
+	l.data[key] = val
+}
+
+//remove deletes the key, the caller must hold the lock
+func (l *MapSequence) remove(key interface{}) {
+	old, ok := l.data[key]
+
+	if !ok {
+		return
+	}
+
+	l.own()
+	l.record(Change{ChangeDelete, key, old, nil})
+	delete(l.data, key)
+
+	i, ok := l.slots[key]
+
+	if !ok {
+		return
+	}
+
+	l.keys[i] = removedKey{}
+	delete(l.slots, key)
+
+	if len(l.keys) > 2*len(l.slots) {
+		l.keys = l.live()
+		l.index()
+	}
+}
+
+//resetKeys forgets the insertion order after the data is wiped, the caller
+//must hold the lock
+func (l *MapSequence) resetKeys() {
+	if l.tracked() {
+		l.keys = make([]interface{}, 0)
+		l.index()
+	}
+}
+
+//syncKeys brings the insertion order in line with replaced data, keeping the
+//order of surviving keys and adding new ones at the end. The caller must hold
+//the lock
+func (l *MapSequence) syncKeys() {
+	if !l.tracked() {
+		return
+	}
+
+	keys := make([]interface{}, 0, len(l.data))
+	seen := make(map[interface{}]bool, len(l.data))
+
+	for _, k := range l.keys {
+		if _, ok := l.data[k]; ok {
+			keys = append(keys, k)
+			seen[k] = true
+		}
+	}
+
+	for k := range l.data {
+		if !seen[k] {
+			keys = append(keys, k)
+		}
+	}
+
+	l.keys = keys
+	l.index()
+}
+
+//cloneOrder gives a clone of the sequence the same ordering mode and
+//insertion order, the caller must hold the lock
+func (l *MapSequence) cloneOrder(cl *MapSequence) *MapSequence {
+	if !l.tracked() {
+		cl.order = l.order
+		return cl
+	}
+
+	cl.keys = l.live()
+	cl.index()
+
+	cl.order = cl.insertionOrder
+	return cl
+}
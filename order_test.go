@@ -0,0 +1,139 @@
+package sequence
+
+import "testing"
+
+func keysOf(it Iterable) []interface{} {
+	var keys []interface{}
+
+	for it.Next() == nil {
+		keys = append(keys, it.Key())
+	}
+
+	return keys
+}
+
+func TestOrderedMapSequence(t *testing.T) {
+	ms := NewOrderedMapSequence(nil, 0)
+
+	for _, k := range []interface{}{"z", "a", "m", "b", "y"} {
+		ms.Add(k, k)
+	}
+
+	want := []interface{}{"z", "a", "m", "b", "y"}
+
+	for i := 0; i < 5; i++ {
+		if keys := keysOf(ms.Iterator()); !sameValues(keys, want) {
+			t.Fatal("ordered map should follow insertion order", keys)
+		}
+	}
+
+	ms.Delete("a")
+	ms.Add("a", 1)
+	ms.Add("z", 2)
+
+	want = []interface{}{"z", "m", "b", "y", "a"}
+
+	if keys := ms.Keys().Obj(); !sameValues(keys, want) {
+		t.Fatal("re-added key should move to the end", keys)
+	}
+
+	if vals := ms.Values().Obj(); vals[0] != 2 || vals[4] != 1 {
+		t.Fatal("values should follow the key order", vals)
+	}
+
+	it := ms.Iterator()
+	first := keysOf(it)
+	it.Reset()
+
+	if again := keysOf(it); !sameValues(first, again) {
+		t.Fatal("reset should replay the same order", first, again)
+	}
+
+	if keys := keysOf(ms.ReverseIterator()); !sameValues(keys, []interface{}{"a", "y", "b", "m", "z"}) {
+		t.Fatal("reverse iterator should walk the order backwards", keys)
+	}
+
+	if keys := ms.Clone().Keys().Obj(); !sameValues(keys, want) {
+		t.Fatal("clone should keep the insertion order", keys)
+	}
+
+	ms.QueueAdd("q", 3)
+	ms.QueueDelete("m")
+	ms.Flush()
+
+	if keys := ms.Keys().Obj(); !sameValues(keys, []interface{}{"z", "b", "y", "a", "q"}) {
+		t.Fatal("queued writes should keep the insertion order", keys)
+	}
+
+	ms.Clear()
+	ms.Add("n", 0)
+
+	if keys := ms.Keys().Obj(); !sameValues(keys, []interface{}{"n"}) {
+		t.Fatal("clear should forget the insertion order", keys)
+	}
+}
+
+func TestOrderedMapBulkDelete(t *testing.T) {
+	ms := NewOrderedMapSequence(nil, 0)
+
+	var evens, odds []interface{}
+	for i := 0; i < 100; i++ {
+		ms.Add(i, i)
+
+		if i%2 == 0 {
+			evens = append(evens, i)
+		} else {
+			odds = append(odds, i)
+		}
+	}
+
+	ms.Delete(evens...)
+
+	if keys := ms.Keys().Obj(); !sameValues(keys, odds) {
+		t.Fatal("bulk delete should keep the order of the rest", keys)
+	}
+
+	ms.Delete(odds[:40]...)
+
+	if len(ms.keys) > 2*len(ms.slots) {
+		t.Fatal("removed keys should be compacted away", len(ms.keys), len(ms.slots))
+	}
+
+	ms.Add(0, 0)
+
+	if keys := ms.Keys().Obj(); !sameValues(keys, append(odds[40:], 0)) {
+		t.Fatal("order should hold after compacting", keys)
+	}
+}
+
+func TestSortedMapSequence(t *testing.T) {
+	ms := NewSortedMapSequence(map[interface{}]interface{}{5: "e", 1: "a", 3: "c"}, 0, intLess)
+	ms.Add(2, "b")
+	ms.Add(4, "d")
+
+	want := []interface{}{1, 2, 3, 4, 5}
+
+	if keys := keysOf(ms.Iterator()); !sameValues(keys, want) {
+		t.Fatal("sorted map should visit keys in order", keys)
+	}
+
+	if vals := ms.Values().Obj(); !sameValues(vals, []interface{}{"a", "b", "c", "d", "e"}) {
+		t.Fatal("sorted map values should follow the keys", vals)
+	}
+
+	if keys := keysOf(NewReverseMapIterator(ms.Obj(), SortedKeys(intLess))); !sameValues(keys, []interface{}{5, 4, 3, 2, 1}) {
+		t.Fatal("reverse map iterator should respect the order", keys)
+	}
+
+	if keys := keysOf(ms.Clone().Iterator().Clone()); !sameValues(keys, want) {
+		t.Fatal("clones should keep the sorted order", keys)
+	}
+}
+
+func TestGroupByOrder(t *testing.T) {
+	groups, _ := GroupBy(NewListIterator([]interface{}{3, 1, 2}), parity)
+
+	if keys := groups.Keys().Obj(); !sameValues(keys, []interface{}{"odd", "even"}) {
+		t.Fatal("groups should be ordered by first appearance", keys)
+	}
+}
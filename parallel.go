@@ -0,0 +1,271 @@
+package sequence
+
+import "sync"
+
+//itemIterator is a fixed single item Iterable, handed to functions that run
+//away from the iterator that produced the item
+type itemIterator struct {
+	key   interface{}
+	value interface{}
+}
+
+//Next always reports the end as the item is already current
+func (i *itemIterator) Next() error {
+	return ErrENDINDEX
+}
+
+//Reset does nothing as the item never moves
+func (i *itemIterator) Reset() {}
+
+//Key returns the key of the item
+func (i *itemIterator) Key() interface{} {
+	return i.key
+}
+
+//Value returns the value of the item
+func (i *itemIterator) Value() interface{} {
+	return i.value
+}
+
+//Length returns 1
+func (i *itemIterator) Length() int {
+	return 1
+}
+
+//Clone returns a copy of the item
+func (i *itemIterator) Clone() Iterable {
+	return &itemIterator{i.key, i.value}
+}
+
+type parallelJob struct {
+	seq   int
+	key   interface{}
+	value interface{}
+}
+
+type parallelResult struct {
+	seq   int
+	key   interface{}
+	value interface{}
+	err   error
+}
+
+//parallelRun holds the goroutines and channels of a single pass over the
+//parent iterator
+type parallelRun struct {
+	results chan parallelResult
+	tokens  chan struct{}
+	quit    chan struct{}
+	end     chan error
+	pending map[int]parallelResult
+	next    int
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+//ParallelIterator runs a ProcFunc over the items of its parent on a bounded
+//pool of goroutines
+type ParallelIterator struct {
+	parent  Iterable
+	workers int
+	proc    ProcFunc
+	ordered bool
+	run     *parallelRun
+	value   interface{}
+	index   interface{}
+	err     error
+}
+
+//ParallelMap returns an iterator running the function over the iterable on
+//the given number of goroutines, yielding the results in the order of the
+//iterable. The first error from the function or the iterable is returned by
+//Next and stops the workers
+func ParallelMap(it Iterable, workers int, fn ProcFunc) *ParallelIterator {
+	return newParallelIterator(it, workers, fn, true)
+}
+
+//ParallelMapUnordered is ParallelMap yielding results as soon as they are done
+//instead of in the order of the iterable
+func ParallelMapUnordered(it Iterable, workers int, fn ProcFunc) *ParallelIterator {
+	return newParallelIterator(it, workers, fn, false)
+}
+
+func newParallelIterator(it Iterable, workers int, fn ProcFunc, ordered bool) *ParallelIterator {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &ParallelIterator{
+		parent:  it.Clone(),
+		workers: workers,
+		proc:    fn,
+		ordered: ordered,
+	}
+}
+
+//start launches the feeder and workers, at most twice the workers count of
+//items are in flight at any time
+func (l *ParallelIterator) start() {
+	r := &parallelRun{
+		results: make(chan parallelResult, l.workers*2),
+		tokens:  make(chan struct{}, l.workers*2),
+		quit:    make(chan struct{}),
+		end:     make(chan error, 1),
+		pending: make(map[int]parallelResult),
+	}
+
+	jobs := make(chan parallelJob)
+	r.wg.Add(l.workers + 1)
+
+	go func() {
+		defer r.wg.Done()
+		defer close(jobs)
+
+		for seq := 0; ; seq++ {
+			select {
+			case r.tokens <- struct{}{}:
+			case <-r.quit:
+				r.end <- ErrENDINDEX
+				return
+			}
+
+			if err := l.parent.Next(); err != nil {
+				<-r.tokens
+				r.end <- err
+				return
+			}
+
+			select {
+			case jobs <- parallelJob{seq, l.parent.Key(), l.parent.Value()}:
+			case <-r.quit:
+				r.end <- ErrENDINDEX
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < l.workers; i++ {
+		go func() {
+			defer r.wg.Done()
+
+			for job := range jobs {
+				v, k, err := l.proc(&itemIterator{job.key, job.value})
+
+				select {
+				case r.results <- parallelResult{job.seq, k, v, err}:
+				case <-r.quit:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		r.wg.Wait()
+		close(r.results)
+	}()
+
+	l.run = r
+}
+
+//Next moves to the next result
+func (l *ParallelIterator) Next() error {
+	if l.err != nil {
+		return l.err
+	}
+
+	if l.run == nil {
+		l.start()
+	}
+
+	r := l.run
+
+	for {
+		if l.ordered {
+			if res, ok := r.pending[r.next]; ok {
+				delete(r.pending, r.next)
+				r.next++
+				return l.emit(res)
+			}
+		}
+
+		res, ok := <-r.results
+
+		if !ok {
+			l.err = <-r.end
+			return l.err
+		}
+
+		if !l.ordered {
+			return l.emit(res)
+		}
+
+		r.pending[res.seq] = res
+	}
+}
+
+func (l *ParallelIterator) emit(res parallelResult) error {
+	<-l.run.tokens
+
+	if res.err != nil {
+		l.err = res.err
+		l.Stop()
+		return res.err
+	}
+
+	l.value = res.value
+	l.index = res.key
+	return nil
+}
+
+//Stop shuts down the workers, waiting for any running function to return,
+//and releases the parent. Later calls to Next return ErrENDINDEX, or the
+//error that stopped the iterator, until Reset is called
+func (l *ParallelIterator) Stop() {
+	if l.run != nil {
+		r := l.run
+		r.once.Do(func() {
+			close(r.quit)
+		})
+		r.wg.Wait()
+	}
+
+	stop(l.parent)
+
+	if l.err == nil {
+		l.err = ErrENDINDEX
+	}
+}
+
+//Reset stops the workers and starts over from the beginning of the parent on
+//the next call to Next
+func (l *ParallelIterator) Reset() {
+	l.Stop()
+	l.parent.Reset()
+	l.run = nil
+	l.err = nil
+	l.value = nil
+	l.index = nil
+}
+
+//Key returns the current index of the iterator
+func (l *ParallelIterator) Key() interface{} {
+	return l.index
+}
+
+//Value returns the value of the data with the index value
+func (l *ParallelIterator) Value() interface{} {
+	return l.value
+}
+
+//Length returns the parent iterators length or UNKNOWNLENGTH if the parent can
+//not tell
+func (l *ParallelIterator) Length() int {
+	n, _ := sizeOf(l.parent)
+	return n
+}
+
+//Clone returns a new iterator off that data
+func (l *ParallelIterator) Clone() Iterable {
+	return newParallelIterator(l.parent, l.workers, l.proc, l.ordered)
+}
@@ -0,0 +1,110 @@
+package sequence
+
+import "errors"
+import "sync/atomic"
+import "testing"
+import "time"
+
+func slowDouble(f Iterable) (interface{}, interface{}, error) {
+	v, _ := f.Value().(int)
+	time.Sleep(time.Duration(10-v%10) * time.Millisecond)
+	return v * 2, f.Key(), nil
+}
+
+func TestParallelMapOrdered(t *testing.T) {
+	items := make([]interface{}, 20)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, peak int32
+	it := ParallelMap(NewListIterator(items), 4, func(f Iterable) (interface{}, interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		defer atomic.AddInt32(&running, -1)
+		return slowDouble(f)
+	})
+
+	if it.Length() != 20 {
+		t.Fatal("parallel map should keep its source length", it.Length())
+	}
+
+	pos := 0
+	for it.Next() == nil {
+		if it.Key() != pos || it.Value() != pos*2 {
+			t.Fatal("ordered parallel map is out of order", it.Key(), it.Value(), pos)
+		}
+		pos++
+	}
+
+	if pos != 20 {
+		t.Fatal("ordered parallel map lost items", pos)
+	}
+
+	if peak > 4 {
+		t.Fatal("parallel map ran more functions than workers", peak)
+	}
+
+	it.Reset()
+
+	if v, err := First(it); err != nil || v != 0 {
+		t.Fatal("reset parallel map should start over", v, err)
+	}
+
+	it.Stop()
+
+	if res := collect(it.Clone()); len(res) != 20 {
+		t.Fatal("cloned parallel map should yield every item", len(res))
+	}
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	it := ParallelMapUnordered(NewListIterator(data), 4, slowDouble)
+
+	total, err := Fold(it, 0, sum)
+
+	if err != nil || total != 192 {
+		t.Fatal("unordered parallel map lost items", total, err)
+	}
+}
+
+func TestParallelMapError(t *testing.T) {
+	fail := errors.New("failed")
+
+	it := ParallelMap(counter(), 3, func(f Iterable) (interface{}, interface{}, error) {
+		if f.Value() == 5 {
+			return nil, nil, fail
+		}
+		return f.Value(), f.Key(), nil
+	})
+
+	n, err := Count(it)
+
+	if err != fail {
+		t.Fatal("parallel map should report the first error", err)
+	}
+
+	if n != 5 {
+		t.Fatal("ordered parallel map should yield every item before the error", n)
+	}
+
+	if it.Next() != fail {
+		t.Fatal("parallel map should keep reporting its error")
+	}
+
+	bad := NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Length() >= 3 {
+			return nil, nil, ErrBADValue
+		}
+		return p.Length(), p.Length(), nil
+	})
+
+	if n, err = Count(ParallelMap(bad, 2, slowDouble)); err != ErrBADValue || n != 3 {
+		t.Fatal("parallel map should report errors from its source after its results", n, err)
+	}
+}
@@ -0,0 +1,168 @@
+package sequence
+
+import "errors"
+
+//each walks the iterable from its current position, calling fn on every item
+//until fn asks to stop or the iterable ends. Reaching ErrENDINDEX is a clean
+//end while every other error, ErrBADValue included, is returned to the caller
+func each(it Iterable, fn func(Iterable) (bool, error)) error {
+	for {
+		err := it.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		more, err := fn(it)
+
+		if err != nil {
+			return err
+		}
+
+		if !more {
+			return nil
+		}
+	}
+}
+
+//Fold consumes the iterable, folding every item into the seed value with the
+//supplied function. Unbounded iterables such as GenerativeIterator should be
+//limited with Take first
+func Fold(it Iterable, seed interface{}, fn ReduceFunc) (interface{}, error) {
+	acc := seed
+
+	err := each(it, func(f Iterable) (bool, error) {
+		res, err := fn(acc, f)
+
+		if err != nil {
+			return false, err
+		}
+
+		acc = res
+		return true, nil
+	})
+
+	return acc, err
+}
+
+//Reduce consumes the iterable, using its first value as the seed for folding
+//the rest of the items. It returns ErrENDINDEX when the iterable is empty
+func Reduce(it Iterable, fn ReduceFunc) (interface{}, error) {
+	var acc interface{}
+	seeded := false
+
+	err := each(it, func(f Iterable) (bool, error) {
+		if !seeded {
+			acc = f.Value()
+			seeded = true
+			return true, nil
+		}
+
+		res, err := fn(acc, f)
+
+		if err != nil {
+			return false, err
+		}
+
+		acc = res
+		return true, nil
+	})
+
+	if err == nil && !seeded {
+		return nil, ErrENDINDEX
+	}
+
+	return acc, err
+}
+
+//Count consumes the iterable and returns the total items it produced
+func Count(it Iterable) (int, error) {
+	count := 0
+
+	err := each(it, func(f Iterable) (bool, error) {
+		count++
+		return true, nil
+	})
+
+	return count, err
+}
+
+//Any returns true as soon as an item passes the predicate
+func Any(it Iterable, fn PredFunc) (bool, error) {
+	found := false
+
+	err := each(it, func(f Iterable) (bool, error) {
+		found = fn(f)
+		return !found, nil
+	})
+
+	return found, err
+}
+
+//All returns false as soon as an item fails the predicate, an empty iterable
+//passes
+func All(it Iterable, fn PredFunc) (bool, error) {
+	passed := true
+
+	err := each(it, func(f Iterable) (bool, error) {
+		passed = fn(f)
+		return passed, nil
+	})
+
+	return passed, err
+}
+
+//First returns the value of the next item of the iterable. It returns
+//ErrENDINDEX when the iterable is empty
+func First(it Iterable) (interface{}, error) {
+	if err := it.Next(); err != nil {
+		return nil, err
+	}
+
+	return it.Value(), nil
+}
+
+//Last consumes the iterable and returns the value of its final item. It
+//returns ErrENDINDEX when the iterable is empty
+func Last(it Iterable) (interface{}, error) {
+	var last interface{}
+	seen := false
+
+	err := each(it, func(f Iterable) (bool, error) {
+		last = f.Value()
+		seen = true
+		return true, nil
+	})
+
+	if err == nil && !seen {
+		return nil, ErrENDINDEX
+	}
+
+	return last, err
+}
+
+//Min consumes the iterable and returns the smallest value according to the
+//less function. It returns ErrENDINDEX when the iterable is empty
+func Min(it Iterable, less LessFunc) (interface{}, error) {
+	return Reduce(it, func(acc interface{}, f Iterable) (interface{}, error) {
+		if less(f.Value(), acc) {
+			return f.Value(), nil
+		}
+		return acc, nil
+	})
+}
+
+//Max consumes the iterable and returns the largest value according to the
+//less function. It returns ErrENDINDEX when the iterable is empty
+func Max(it Iterable, less LessFunc) (interface{}, error) {
+	return Reduce(it, func(acc interface{}, f Iterable) (interface{}, error) {
+		if less(acc, f.Value()) {
+			return f.Value(), nil
+		}
+		return acc, nil
+	})
+}
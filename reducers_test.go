@@ -0,0 +1,121 @@
+package sequence
+
+import "testing"
+
+func intLess(a, b interface{}) bool {
+	x, _ := a.(int)
+	y, _ := b.(int)
+	return x < y
+}
+
+func sum(acc interface{}, f Iterable) (interface{}, error) {
+	a, _ := acc.(int)
+	v, _ := f.Value().(int)
+	return a + v, nil
+}
+
+func TestFoldAndReduce(t *testing.T) {
+	total, err := Fold(NewListIterator(data), 100, sum)
+
+	if err != nil || total != 196 {
+		t.Fatal("fold total is incorrect", total, err)
+	}
+
+	total, err = Reduce(NewListIterator(data), sum)
+
+	if err != nil || total != 96 {
+		t.Fatal("reduce total is incorrect", total, err)
+	}
+
+	if _, err = Reduce(NewListIterator(nil), sum); err != ErrENDINDEX {
+		t.Fatal("reduce over an empty list should return ErrENDINDEX", err)
+	}
+
+	total, err = Fold(Take(counter(), 5), 0, sum)
+
+	if err != nil || total != 10 {
+		t.Fatal("fold over a limited generator is incorrect", total, err)
+	}
+}
+
+func TestCountAnyAll(t *testing.T) {
+	if n, err := Count(NewListIterator(data)); err != nil || n != len(data) {
+		t.Fatal("count is incorrect", n, err)
+	}
+
+	seen := 0
+	gen := Map(counter(), func(f Iterable) (interface{}, interface{}, error) {
+		seen++
+		return f.Value(), f.Key(), nil
+	})
+
+	found, err := Any(gen, func(f Iterable) bool {
+		return f.Value() == 3
+	})
+
+	if err != nil || !found {
+		t.Fatal("any should find 3 in the generator", found, err)
+	}
+
+	if seen != 4 {
+		t.Fatal("any should stop on the first match", seen)
+	}
+
+	ok, err := All(counter(), func(f Iterable) bool {
+		v, _ := f.Value().(int)
+		return v < 10
+	})
+
+	if err != nil || ok {
+		t.Fatal("all should stop on the first failure", ok, err)
+	}
+
+	if ok, _ = All(NewListIterator(nil), even); !ok {
+		t.Fatal("all over an empty list should pass")
+	}
+}
+
+func TestFirstLastMinMax(t *testing.T) {
+	if v, err := First(counter()); err != nil || v != 0 {
+		t.Fatal("first value of the generator is incorrect", v, err)
+	}
+
+	if _, err := First(NewListIterator(nil)); err != ErrENDINDEX {
+		t.Fatal("first of an empty list should return ErrENDINDEX", err)
+	}
+
+	if v, err := Last(NewListIterator(data)); err != nil || v != 7 {
+		t.Fatal("last value is incorrect", v, err)
+	}
+
+	if v, err := Min(NewListIterator(data), intLess); err != nil || v != 1 {
+		t.Fatal("min value is incorrect", v, err)
+	}
+
+	if v, err := Max(NewListIterator(data), intLess); err != nil || v != 56 {
+		t.Fatal("max value is incorrect", v, err)
+	}
+
+	if _, err := Max(NewListIterator(nil), intLess); err != ErrENDINDEX {
+		t.Fatal("max of an empty list should return ErrENDINDEX", err)
+	}
+}
+
+func TestReducerBadValue(t *testing.T) {
+	bad := NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Length() >= 2 {
+			return nil, nil, ErrBADValue
+		}
+		return p.Length(), p.Length(), nil
+	})
+
+	n, err := Count(bad)
+
+	if err != ErrBADValue {
+		t.Fatal("count should report ErrBADValue instead of ending", err)
+	}
+
+	if n != 2 {
+		t.Fatal("count should hold the items seen before the bad value", n)
+	}
+}
@@ -0,0 +1,192 @@
+package sequence
+
+import "iter"
+
+//stopper is implemented by iterators holding resources that must be released
+//when they are abandoned before their end
+type stopper interface {
+	Stop()
+}
+
+//stop releases the iterable if it holds resources. Iterators wrapping others
+//forward Stop to them, so stopping the outermost one is enough
+func stop(it Iterable) {
+	if s, ok := it.(stopper); ok {
+		s.Stop()
+	}
+}
+
+//Seq2 returns a range-over-func iterator yielding the keys and values of a
+//clone of the iterable, so every range starts from where the iterable is:
+//the beginning for iterators over data, the current position for
+//generators. Ranging stops at the first error, use the reducers when errors
+//must be seen
+func Seq2(it Iterable) iter.Seq2[interface{}, interface{}] {
+	return func(yield func(interface{}, interface{}) bool) {
+		c := it.Clone()
+		defer stop(c)
+
+		for c.Next() == nil {
+			if !yield(c.Key(), c.Value()) {
+				return
+			}
+		}
+	}
+}
+
+//Seq returns a range-over-func iterator yielding the values of a clone of the
+//iterable
+func Seq(it Iterable) iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for _, v := range Seq2(it) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+//All returns a range-over-func iterator over the keys and values of the
+//sequence
+func (t *IterableSequence) All() iter.Seq2[interface{}, interface{}] {
+	return Seq2(t.Iterator())
+}
+
+//All returns a range-over-func iterator over the indexes and values of the
+//sequence
+func (l *ListSequence) All() iter.Seq2[interface{}, interface{}] {
+	return Seq2(l.Iterator())
+}
+
+//All returns a range-over-func iterator over the keys and values of the
+//sequence
+func (l *MapSequence) All() iter.Seq2[interface{}, interface{}] {
+	return Seq2(l.Iterator())
+}
+
+//SeqIterator provides an Iterable over a range-over-func iterator, pulling
+//one item at a time from it
+type SeqIterator struct {
+	src   iter.Seq2[interface{}, interface{}]
+	next  func() (interface{}, interface{}, bool)
+	stop  func()
+	key   interface{}
+	value interface{}
+	done  bool
+}
+
+//FromSeq2 returns an Iterable over a standard library iter.Seq2 such as
+//maps.All. The iterable must be ranged to its end or stopped with Stop,
+//stopping an iterator wrapping it stops it too
+func FromSeq2[K, V any](s iter.Seq2[K, V]) *SeqIterator {
+	return &SeqIterator{
+		src: func(yield func(interface{}, interface{}) bool) {
+			for k, v := range s {
+				if !yield(k, v) {
+					return
+				}
+			}
+		},
+	}
+}
+
+//FromSeq returns an Iterable over a standard library iter.Seq such as
+//slices.Values, keyed by the position of each value. The iterable must be
+//ranged to its end or stopped with Stop, stopping an iterator wrapping it
+//stops it too
+func FromSeq[V any](s iter.Seq[V]) *SeqIterator {
+	return &SeqIterator{
+		src: func(yield func(interface{}, interface{}) bool) {
+			i := 0
+			for v := range s {
+				if !yield(i, v) {
+					return
+				}
+				i++
+			}
+		},
+	}
+}
+
+//Next moves to the next item
+func (s *SeqIterator) Next() error {
+	if s.done {
+		return ErrENDINDEX
+	}
+
+	if s.next == nil {
+		s.next, s.stop = iter.Pull2(s.src)
+	}
+
+	k, v, ok := s.next()
+
+	if !ok {
+		s.Stop()
+		return ErrENDINDEX
+	}
+
+	s.key = k
+	s.value = v
+	return nil
+}
+
+//Stop releases the underlying pull iterator, later calls to Next return
+//ErrENDINDEX until Reset is called
+func (s *SeqIterator) Stop() {
+	if s.stop != nil {
+		s.stop()
+	}
+
+	s.next = nil
+	s.stop = nil
+	s.done = true
+}
+
+//Reset stops the current pull and starts the range again on the next call to
+//Next
+func (s *SeqIterator) Reset() {
+	s.Stop()
+	s.key = nil
+	s.value = nil
+	s.done = false
+}
+
+//Key returns the current index of the iterator
+func (s *SeqIterator) Key() interface{} {
+	return s.key
+}
+
+//Value returns the value of the data with the index value
+func (s *SeqIterator) Value() interface{} {
+	return s.value
+}
+
+//Length returns UNKNOWNLENGTH as a range-over-func iterator has no size
+func (s *SeqIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (s *SeqIterator) Clone() Iterable {
+	return &SeqIterator{src: s.src}
+}
+
+//PullGenerator returns a GenerativeIterator driven by a push-style producer,
+//each call to Next pulls the next key and value the producer yields. The
+//returned stop function must be called if the generator is abandoned before
+//the producer is done, clones share the same producer state
+func PullGenerator(producer iter.Seq2[interface{}, interface{}]) (*GenerativeIterator, func()) {
+	next, stop := iter.Pull2(producer)
+
+	gen := NewGenerativeIterator(func(f Iterable) (interface{}, interface{}, error) {
+		k, v, ok := next()
+
+		if !ok {
+			return nil, nil, ErrENDINDEX
+		}
+
+		return v, k, nil
+	})
+
+	return gen, stop
+}
@@ -0,0 +1,159 @@
+package sequence
+
+import "maps"
+import "slices"
+import "testing"
+
+func TestRangeOverSequences(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 32, 56, 7}, 0)
+
+	for k, v := range ls.All() {
+		ind, _ := k.(int)
+		if data[ind] != v {
+			t.Fatal("Index and value incorrect with list", k, v)
+		}
+	}
+
+	ms := NewMapSequence(map[interface{}]interface{}{1: "a", 2: "b"}, 0)
+	seen := 0
+
+	for k, v := range ms.All() {
+		if ms.Get(k) != v {
+			t.Fatal("key and value incorrect with map", k, v)
+		}
+		seen++
+	}
+
+	if seen != 2 {
+		t.Fatal("map range should visit every key", seen)
+	}
+
+	is := NewIterableSequence(NewListIterator(data))
+	var vals []interface{}
+
+	for v := range Seq(Filter(is.Iterator(), even)) {
+		vals = append(vals, v)
+	}
+
+	if !sameValues(vals, []interface{}{32, 56}) {
+		t.Fatal("range over an iterable is incorrect", vals)
+	}
+
+	n := 0
+	for range is.All() {
+		n++
+	}
+
+	if n != len(data) {
+		t.Fatal("iterable sequence should range from the start every time", n)
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	it := FromSeq(slices.Values([]string{"a", "b", "c"}))
+
+	if res := collect(it); !sameValues(res, []interface{}{"a", "b", "c"}) {
+		t.Fatal("values from slices.Values are incorrect", res)
+	}
+
+	if it.Key() != 2 {
+		t.Fatal("keys should be positions", it.Key())
+	}
+
+	it.Reset()
+
+	if v, err := First(it); err != nil || v != "a" {
+		t.Fatal("reset should restart the range", v, err)
+	}
+
+	it.Stop()
+
+	if it.Next() != ErrENDINDEX {
+		t.Fatal("stopped iterator should be at its end")
+	}
+
+	m := map[string]int{"x": 1, "y": 2}
+	total, err := Fold(FromSeq2(maps.All(m)), 0, func(acc interface{}, f Iterable) (interface{}, error) {
+		if m[f.Key().(string)] != f.Value() {
+			t.Fatal("key and value incorrect with map", f.Key(), f.Value())
+		}
+		return acc.(int) + f.Value().(int), nil
+	})
+
+	if err != nil || total != 3 {
+		t.Fatal("values from maps.All are incorrect", total, err)
+	}
+}
+
+func TestRangeBreakStops(t *testing.T) {
+	done := false
+	src := FromSeq(func(yield func(int) bool) {
+		defer func() { done = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+
+	for v := range Seq(src) {
+		if v == 3 {
+			break
+		}
+	}
+
+	if !done {
+		t.Fatal("breaking out of a range should stop the producer")
+	}
+}
+
+func TestWrappedRangeBreakStops(t *testing.T) {
+	done := 0
+	src := FromSeq(func(yield func(int) bool) {
+		defer func() { done++ }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	})
+
+	for v := range Seq(Map(Take(Filter(src, even), 5), func(f Iterable) (interface{}, interface{}, error) {
+		return f.Value(), f.Key(), nil
+	})) {
+		if v == 4 {
+			break
+		}
+	}
+
+	if done != 1 {
+		t.Fatal("breaking out of a range over wrapped iterators should stop the producer", done)
+	}
+
+	zip := Zip(Take(src, 2), src)
+	zip.Next()
+	zip.Stop()
+
+	if done != 3 {
+		t.Fatal("stopping a wrapper should stop every producer under it", done)
+	}
+}
+
+func TestPullGenerator(t *testing.T) {
+	gen, stop := PullGenerator(func(yield func(interface{}, interface{}) bool) {
+		for i := 0; i < 3; i++ {
+			if !yield(i, i*10) {
+				return
+			}
+		}
+	})
+	defer stop()
+
+	if res := collect(gen); !sameValues(res, []interface{}{0, 10, 20}) {
+		t.Fatal("pulled values are incorrect", res)
+	}
+
+	if gen.Key() != 2 {
+		t.Fatal("pulled keys are incorrect", gen.Key())
+	}
+}
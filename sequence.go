@@ -2,11 +2,18 @@ package sequence
 
 import "errors"
 
+import "fmt"
+
 import "sync"
 
+import "sync/atomic"
+
 const (
 	//MINBUFF states the default minimum buffer size for the write channels
 	MINBUFF = 20
+	//UNKNOWNLENGTH is reported by iterators whoes length can not be known
+	//without running them
+	UNKNOWNLENGTH = -1
 )
 
 var (
@@ -16,6 +23,23 @@ var (
 	ErrBADINDEX = errors.New("Bad Index!")
 	//ErrENDINDEX represents a reaching of the end of an iterator
 	ErrENDINDEX = errors.New("End Index!")
+	//ErrFull represents a push onto a bounded sequence with no room left
+	ErrFull = errors.New("Sequence Full!")
+	//ErrEmpty represents a pop or peek on a sequence with no items
+	ErrEmpty = errors.New("Sequence Empty!")
+	//ErrTxDone represents use of a transaction already committed or rolled back
+	ErrTxDone = errors.New("Transaction Done!")
+	//ErrKeyType represents a key of the wrong type for the sequence
+	ErrKeyType = errors.New("Bad Key Type!")
+	//ErrOutOfRange represents an index outside of the sequence
+	ErrOutOfRange = errors.New("Index Out Of Range!")
+	//ErrOddPairs represents a key without a value in a list of key value
+	//pairs, it is an ErrBADValue
+	ErrOddPairs = fmt.Errorf("Odd Key Value Pairs! %w", ErrBADValue)
+	//ErrNotFound represents a key that is not in the sequence
+	ErrNotFound = errors.New("Key Not Found!")
+	//ErrResultType represents a Mutate function returning the wrong type
+	ErrResultType = errors.New("Bad Result Type!")
 )
 
 //MutFunc is the type of a function whoes argument is a Sequencable
@@ -24,7 +48,24 @@ type MutFunc func(f interface{}) interface{}
 //ProcFunc is the type of a function giving to a BaseIterator
 type ProcFunc func(f Iterable) (interface{}, interface{}, error)
 
-//Iterable defines sequence method rules
+//PredFunc is the type of a function that tests the current state of an Iterable
+type PredFunc func(f Iterable) bool
+
+//KeyFunc is the type of a function deriving a grouping key from the current
+//state of an Iterable
+type KeyFunc func(f Iterable) interface{}
+
+//ReduceFunc is the type of a function folding the current state of an Iterable
+//into an accumulated value
+type ReduceFunc func(acc interface{}, f Iterable) (interface{}, error)
+
+//LessFunc is the type of a function reporting if a is ordered before b
+type LessFunc func(a, b interface{}) bool
+
+//Iterable defines sequence method rules. Clone returns a new iterator off the
+//same source, iterators over data start it from the beginning while
+//generators carry on from their current position. Iterators built off
+//another clone it, so over a generator they carry on too
 type Iterable interface {
 	Next() error
 	Key() interface{}
@@ -34,6 +75,29 @@ type Iterable interface {
 	Clone() Iterable
 }
 
+//Peeker is implemented by iterators that can look at the next item without
+//moving to it, an iterator at its end returns ErrENDINDEX
+type Peeker interface {
+	Iterable
+	Peek() (interface{}, interface{}, error)
+}
+
+//Seeker is implemented by iterators that can jump to an item by its key or
+//by its position in the walk, making it the current item
+type Seeker interface {
+	Iterable
+	Seek(interface{}) error
+	SeekIndex(int) error
+}
+
+//BidiIterable is implemented by iterators that can step back, Prev moves to
+//the item before the current one and returns ErrENDINDEX once it passes the
+//first, leaving the iterator as if just Reset
+type BidiIterable interface {
+	Iterable
+	Prev() error
+}
+
 //Sequencable defines a sequence method rules
 type Sequencable interface {
 	Iterator() Iterable
@@ -90,6 +154,47 @@ type MapSequencable interface {
 	Values() ListSequencable
 }
 
+//SetSequencable defines SetSequence method rules
+type SetSequencable interface {
+	MutableSizableSequencable
+	Obj() []interface{}
+	Clear() SetSequencable
+	Add(...interface{}) SetSequencable
+	Delete(...interface{}) SetSequencable
+	Has(interface{}) bool
+	Clone() SetSequencable
+	Union(...SetSequencable) SetSequencable
+	Intersect(...SetSequencable) SetSequencable
+	Difference(...SetSequencable) SetSequencable
+	SymmetricDifference(...SetSequencable) SetSequencable
+}
+
+//ImmutableListSequencable defines the method rules of a persistent list,
+//every change returns a new version sharing structure with the old one
+type ImmutableListSequencable interface {
+	SizableSequencable
+	Obj() []interface{}
+	Clear() ImmutableListSequencable
+	Add(...interface{}) ImmutableListSequencable
+	Delete(...interface{}) ImmutableListSequencable
+	Get(interface{}) interface{}
+	Keys() ListSequencable
+	Values() ListSequencable
+}
+
+//ImmutableMapSequencable defines the method rules of a persistent map,
+//every change returns a new version sharing structure with the old one
+type ImmutableMapSequencable interface {
+	SizableSequencable
+	Obj() map[interface{}]interface{}
+	Clear() ImmutableMapSequencable
+	Add(...interface{}) ImmutableMapSequencable
+	Delete(...interface{}) ImmutableMapSequencable
+	Get(interface{}) interface{}
+	Keys() ListSequencable
+	Values() ListSequencable
+}
+
 //IterableSequence is the root level of immutable sequence types
 type IterableSequence struct {
 	*Sequence
@@ -127,8 +232,11 @@ func NewIterableSequence(f Iterable) *IterableSequence {
 //Sequence is the root level structure for all sequence types
 type Sequence struct {
 	parent Sequencable
-	// writer *SeqWriter
-	lock *sync.RWMutex
+	writer *SeqWriter
+	lock   *sync.RWMutex
+	notes  *notifier
+	buff   int
+	once   sync.Once
 }
 
 //Iterator returns the iterator of the sequence
@@ -153,12 +261,33 @@ func NewBaseSequence(buff int, parent Sequencable) *Sequence {
 		buff = MINBUFF
 	}
 
+	lock := new(sync.RWMutex)
+
 	return &Sequence{
 		parent,
-		new(sync.RWMutex),
+		nil,
+		lock,
+		newNotifier(buff),
+		buff,
+		sync.Once{},
 	}
 }
 
+//queue returns the writer of the sequence, making it on first use so
+//sequences that never queue writes do not pay for its channel
+func (s *Sequence) queue() *SeqWriter {
+	s.once.Do(func() {
+		s.writer = NewSeqWriter(s.buff, s.lock)
+		s.writer.unlock = s.unlockAndNotify
+	})
+	return s.writer
+}
+
+//Flush blocks until every write queued on the sequence has been applied
+func (s *Sequence) Flush() {
+	s.queue().Flush()
+}
+
 //NewListSequence returns a new ListSequence
 func NewListSequence(data []interface{}, buff int) *ListSequence {
 	if data == nil {
@@ -169,6 +298,7 @@ func NewListSequence(data []interface{}, buff int) *ListSequence {
 		NewBaseSequence(buff, nil),
 		data,
 		buff,
+		atomic.Bool{},
 	}
 }
 
@@ -182,32 +312,45 @@ func NewMapSequence(data map[interface{}]interface{}, buff int) *MapSequence {
 		NewBaseSequence(buff, nil),
 		data,
 		buff,
+		nil,
+		nil,
+		nil,
+		atomic.Bool{},
 	}
 }
 
-//MapSequence represents a sequence for maps
+//MapSequence represents a sequence for maps. Iterator and Obj hand out
+//snapshots of the data, writes made after them copy the map first
 type MapSequence struct {
 	*Sequence
 	data   map[interface{}]interface{}
 	buffer int
+	order  KeyOrder
+	keys   []interface{}
+	slots  map[interface{}]int
+	shared atomic.Bool
 }
 
-//Mutate allows mutation on sequence data
+//Mutate allows mutation on sequence data, a result that is not a map is
+//ignored
 func (l *MapSequence) Mutate(fn MutFunc) {
 	l.lock.Lock()
-	res, ok := fn(l.data).(map[interface{}]interface{})
-
-	if !ok {
-		return
-	}
-
-	l.data = res
-	l.lock.Unlock()
+	defer l.unlockAndNotify()
+	l.mutate(fn)
 }
 
-//Iterator returns the sequence data iterator
+//Iterator returns an iterator over a snapshot of the sequence data, writes
+//made after it is taken are not seen by it
 func (l *MapSequence) Iterator() Iterable {
-	return NewMapIterator(l.data)
+	data, order := l.snapshot()
+	return NewMapIterator(data, order)
+}
+
+//ReverseIterator returns an iterator walking a snapshot of the sequence data
+//in the reverse of its order
+func (l *MapSequence) ReverseIterator() Iterable {
+	data, order := l.snapshot()
+	return NewReverseMapIterator(data, order)
 }
 
 //Parent returns the sequence as a sequencable
@@ -227,27 +370,32 @@ func (l *ListSequence) Value() interface{} {
 
 //Get retrieves the value
 func (l *MapSequence) Get(d interface{}) interface{} {
-	l.lock.RLock()
-	f := l.data[d]
-	l.lock.RUnlock()
+	f, _ := l.GetE(d)
 	return f
 }
 
 //Clone copies internal structure data
 func (l *MapSequence) Clone() MapSequencable {
-	// l.data = make([]interface{}, 0)
-	nd := make(map[interface{}]interface{})
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	nd := make(map[interface{}]interface{}, len(l.data))
 
 	for k, v := range l.data {
 		nd[k] = v
 	}
 
-	return NewMapSequence(nd, l.buffer)
+	return l.cloneOrder(NewMapSequence(nd, l.buffer))
 }
 
 //Clear wipes internal structure data
 func (l *MapSequence) Clear() MapSequencable {
+	l.lock.Lock()
+	l.record(Change{ChangeClear, nil, l.data, nil})
 	l.data = make(map[interface{}]interface{})
+	l.shared.Store(false)
+	l.resetKeys()
+	l.unlockAndNotify()
 	return l
 }
 
@@ -259,41 +407,30 @@ func (l *MapSequence) Length() int {
 	return sz
 }
 
-//Obj returns the sequence data in the format of its input
+//Obj returns a snapshot of the sequence data in the format of its input, it
+//is shared with the sequence until the next write and must not be modified
 func (l *MapSequence) Obj() map[interface{}]interface{} {
 	l.lock.RLock()
 	m := l.data
+	l.shared.Store(true)
 	l.lock.RUnlock()
 	return m
 }
 
-//Add for the ListSequence adds all supplied arguments at once to the list
+//Add sets each supplied key and value pair, an odd trailing key is ignored.
+//Use AddE to have it reported as ErrOddPairs
 func (l *MapSequence) Add(f ...interface{}) MapSequencable {
-	// l.writer.Stack(func() {
 	l.lock.Lock()
-	key := f[0]
-	val := f[1]
-	l.data[key] = val
-	l.lock.Unlock()
-	// })
-	// l.writer.Flush()
+	defer l.unlockAndNotify()
+	l.add(f...)
 	return l
 }
 
-//Delete for the ListSequence adds all supplied arguments at once to the list
+//Delete removes the supplied keys, skipping those not in the map
 func (l *MapSequence) Delete(f ...interface{}) MapSequencable {
 	l.lock.Lock()
-	for _, v := range f {
-		_, ok := l.data[v]
-
-		if !ok {
-			return l
-		}
-
-		delete(l.data, v)
-	}
-	l.lock.Unlock()
-
+	defer l.unlockAndNotify()
+	l.del(f...)
 	return l
 }
 
@@ -321,37 +458,38 @@ func (l *MapSequence) Keys() ListSequencable {
 	return kl
 }
 
-//ListSequence represents a sequence for arrays,splice type structures
+//ListSequence represents a sequence for arrays,splice type structures.
+//Iterator and Obj hand out snapshots of the data, writes made after them
+//copy the slice first
 type ListSequence struct {
 	*Sequence
 	data   []interface{}
 	buffer int
+	shared atomic.Bool
 }
 
-//Mutate allows mutation on sequence data
+//Mutate allows mutation on sequence data, a result that is not a list is
+//ignored
 func (l *ListSequence) Mutate(fn MutFunc) {
 	l.lock.Lock()
-	res, ok := fn(l.data).([]interface{})
-
-	if !ok {
-		return
-	}
-
-	l.data = res
-	l.lock.Unlock()
+	defer l.unlockAndNotify()
+	l.mutate(fn)
 }
 
-//Obj returns the sequence data in the format of its input
+//Obj returns a snapshot of the sequence data in the format of its input, it
+//is shared with the sequence until the next write and must not be modified
 func (l *ListSequence) Obj() []interface{} {
 	l.lock.RLock()
 	d := l.data
+	l.shared.Store(true)
 	l.lock.RUnlock()
 	return d
 }
 
-//Iterator returns the sequence data iterator
+//Iterator returns an iterator over a snapshot of the sequence data, writes
+//made after it is taken are not seen by it
 func (l *ListSequence) Iterator() Iterable {
-	return NewListIterator(l.data)
+	return NewListIterator(l.Obj())
 }
 
 //Parent returns the sequence as a sequencable
@@ -376,32 +514,28 @@ func (l *ListSequence) Keys() ListSequencable {
 	return kl
 }
 
-//Get retrieves the value
+//Get retrieves the value, nil if the key is not an int in range
 func (l *ListSequence) Get(d interface{}) interface{} {
-	dd, ok := d.(int)
-
-	if !ok {
-		return nil
-	}
-
-	l.lock.RLock()
-	val := l.data[dd]
-	l.lock.RUnlock()
-
+	val, _ := l.GetE(d)
 	return val
 }
 
 //Clone copies internal structure data
 func (l *ListSequence) Clone() ListSequencable {
-	// l.data = make([]interface{}, 0)
-	nd := make([]interface{}, l.Length())
+	l.lock.RLock()
+	nd := make([]interface{}, len(l.data))
 	copy(nd, l.data)
+	l.lock.RUnlock()
 	return NewListSequence(nd, l.buffer)
 }
 
 //Clear wipes internal structure data
 func (l *ListSequence) Clear() ListSequencable {
+	l.lock.Lock()
+	l.record(Change{ChangeClear, nil, l.data, nil})
 	l.data = make([]interface{}, 0)
+	l.shared.Store(false)
+	l.unlockAndNotify()
 	return l
 }
 
@@ -416,37 +550,17 @@ func (l *ListSequence) Length() int {
 //Add for the ListSequence adds all supplied arguments at once to the list
 func (l *ListSequence) Add(f ...interface{}) ListSequencable {
 	l.lock.Lock()
-	l.data = append(l.data, f...)
-	l.lock.Unlock()
+	l.add(f...)
+	l.unlockAndNotify()
 	return l
 }
 
-//Delete for the ListSequence adds all supplied arguments at once to the list
+//Delete removes the items at each index in turn, skipping keys that are not
+//ints in range
 func (l *ListSequence) Delete(f ...interface{}) ListSequencable {
-	if len(l.data) <= 0 {
-		return l
-	}
-
-	for _, v := range f {
-
-		i, ok := v.(int)
-
-		if !ok {
-			return l
-		}
-
-		if i <= 0 && len(l.data) <= 0 {
-			return l
-		}
-
-		l.lock.Lock()
-		copy(l.data[i:], l.data[i+1:])
-		l.data[len(l.data)-1] = nil
-		l.data = l.data[:len(l.data)-1]
-		l.lock.Unlock()
-
-	}
-
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+	l.del(f...)
 	return l
 }
 
@@ -454,7 +568,9 @@ func (l *ListSequence) Delete(f ...interface{}) ListSequencable {
 type MapIterator struct {
 	Iterable
 	data    map[interface{}]interface{}
-	updater func(*MapIterator)
+	order   KeyOrder
+	reverse bool
+	keys    []interface{}
 }
 
 //GrabKeys returns a list of the given map keys
@@ -470,30 +586,33 @@ func GrabKeys(b map[interface{}]interface{}) []interface{} {
 	return keys
 }
 
-//NewMapIterator returns a new mapiterator for use
-func NewMapIterator(m map[interface{}]interface{}) *MapIterator {
-	keys := GrabKeys(m)
-	kit := NewListIterator(keys)
-
-	upd := func(f *MapIterator) {
-		keys = GrabKeys(f.data)
-		f.Iterable = NewListIterator(keys)
-	}
+//NewMapIterator returns a new mapiterator for use, visiting the keys in the
+//order given by the KeyOrder if one is supplied or in Go map order otherwise
+func NewMapIterator(m map[interface{}]interface{}, order ...KeyOrder) *MapIterator {
+	mi := &MapIterator{nil, m, pickOrder(order), false, nil}
+	mi.updater()
+	return mi
+}
 
-	return &MapIterator{Iterable(kit), m, upd}
+//NewReverseMapIterator returns a new mapiterator for use, visiting the keys in
+//the reverse of the order given by the KeyOrder if one is supplied
+func NewReverseMapIterator(m map[interface{}]interface{}, order ...KeyOrder) *MapIterator {
+	mi := &MapIterator{nil, m, pickOrder(order), true, nil}
+	mi.updater()
+	return mi
 }
 
-//NewReverseMapIterator returns a new mapiterator for use
-func NewReverseMapIterator(m map[interface{}]interface{}) *MapIterator {
-	keys := GrabKeys(m)
-	kit := NewReverseListIterator(keys)
+//updater grabs the keys of the map again in the iterators order
+func (m *MapIterator) updater() {
+	keys := m.order(m.data)
+	m.keys = keys
 
-	upd := func(f *MapIterator) {
-		keys = GrabKeys(f.data)
-		f.Iterable = NewReverseListIterator(keys)
+	if m.reverse {
+		m.Iterable = NewReverseListIterator(keys)
+		return
 	}
 
-	return &MapIterator{Iterable(kit), m, upd}
+	m.Iterable = NewListIterator(keys)
 }
 
 //GenerativeIterator is the base iterator for creating custom iterator
@@ -525,19 +644,19 @@ func (l *GenerativeIterator) hasNext() bool {
 //Next moves to the next item
 func (l *GenerativeIterator) Next() error {
 	if !l.hasNext() {
-		return ErrBADINDEX
+		return ErrENDINDEX
 	}
 
 	v, k, err := l.proc(l)
 
-	if err == ErrBADValue {
+	if errors.Is(err, ErrBADValue) {
 		l.value = nil
 		l.index = nil
 		l.can = false
-		return ErrBADValue
+		return err
 	}
 
-	if err == ErrENDINDEX {
+	if errors.Is(err, ErrENDINDEX) {
 		l.can = false
 		return err
 	}
@@ -548,10 +667,13 @@ func (l *GenerativeIterator) Next() error {
 	return err
 }
 
-//Reset reverst the iterators index
+//Reset reverst the iterators index, letting an ended iterator generate
+//again. State captured by its ProcFunc is not reset, use
+//NewStatefulGenerator when the generator needs that
 func (l *GenerativeIterator) Reset() {
 	l.value = nil
 	l.index = nil
+	l.can = true
 	l.count = 0
 }
 
@@ -570,17 +692,21 @@ func (l *GenerativeIterator) Length() int {
 	return l.count
 }
 
-//Clone returns a new iterator off that data
+//Clone returns a new iterator carrying on from the current position, it
+//shares any state captured by the ProcFunc
 func (l *GenerativeIterator) Clone() Iterable {
-	return NewGenerativeIterator(l.proc)
+	gi := *l
+	return &gi
 }
 
-//BaseIterator handles interation over an iterator
+//BaseIterator handles interation over an iterator. Failures of its parent or
+//ProcFunc come back as an *IterError holding the position they happened at
 type BaseIterator struct {
 	parent Iterable
 	value  interface{}
 	index  interface{}
 	proc   ProcFunc
+	pos    int
 }
 
 //IdentityIterator takes an Iterable and returns an iterator that simple returns
@@ -598,6 +724,7 @@ func NewBaseIterator(b Iterable, fn ProcFunc) *BaseIterator {
 		nil,
 		nil,
 		fn,
+		-1,
 	}
 }
 
@@ -605,22 +732,23 @@ func NewBaseIterator(b Iterable, fn ProcFunc) *BaseIterator {
 func (l *BaseIterator) Next() error {
 	err := l.parent.Next()
 
-	if err == ErrBADValue {
+	if errors.Is(err, ErrBADValue) {
 		l.value = nil
 		l.index = nil
-		return ErrBADValue
+		return wrapErr(err, l.pos+1, nil)
 	}
 
 	if err != nil {
-		return err
+		return wrapErr(err, l.pos+1, nil)
 	}
 
 	v, k, err := l.proc(l.parent)
 
 	if err != nil {
-		return err
+		return wrapErr(err, l.pos+1, l.parent.Key())
 	}
 
+	l.pos++
 	l.value = v
 	l.index = k
 	return nil
@@ -631,6 +759,12 @@ func (l *BaseIterator) Reset() {
 	l.parent.Reset()
 	l.value = nil
 	l.index = nil
+	l.pos = -1
+}
+
+//Stop releases the parent iterator if it holds resources
+func (l *BaseIterator) Stop() {
+	stop(l.parent)
 }
 
 //Key returns the current index of the iterator
@@ -663,7 +797,7 @@ type ListIterator struct {
 func (m *MapIterator) Next() error {
 	err := m.Iterable.Next()
 	if m.Iterable.Length() != len(m.data) {
-		m.updater(m)
+		m.updater()
 	}
 	return err
 }
@@ -686,7 +820,10 @@ func (m *MapIterator) Length() int {
 
 //Clone returns a new iterator off that data
 func (m *MapIterator) Clone() Iterable {
-	return NewMapIterator(m.data)
+	if m.reverse {
+		return NewReverseMapIterator(m.data, m.order)
+	}
+	return NewMapIterator(m.data, m.order)
 }
 
 //ReverseListIterator returns a reverse iterator
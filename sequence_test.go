@@ -45,6 +45,35 @@ func TestSequence(t *testing.T) {
 	}
 }
 
+func TestGenerativeIteratorRestart(t *testing.T) {
+	gen := NewGenerativeIterator(func(p Iterable) (interface{}, interface{}, error) {
+		if p.Length() >= 3 {
+			return nil, nil, ErrENDINDEX
+		}
+		return p.Length() * 10, p.Length(), nil
+	})
+
+	gen.Next()
+	cl := gen.Clone()
+
+	if cl.Next() != nil || cl.Value() != 10 || gen.Value() != 0 {
+		t.Fatal("clone should carry on from the current position", cl.Value(), gen.Value())
+	}
+
+	for gen.Next() == nil {
+	}
+
+	if err := gen.Next(); err != ErrENDINDEX {
+		t.Fatal("ended generator should keep returning ErrENDINDEX", err)
+	}
+
+	gen.Reset()
+
+	if err := gen.Next(); err != nil || gen.Value() != 0 {
+		t.Fatal("reset generator should generate again", gen.Value(), err)
+	}
+}
+
 func TestEmptyList(t *testing.T) {
 	li := NewListIterator(make([]interface{}, 0))
 
@@ -0,0 +1,464 @@
+package sequence
+
+import "errors"
+
+//SetSequence represents a sequence of unique items kept in the order they
+//were added
+type SetSequence struct {
+	*Sequence
+	index  map[interface{}]struct{}
+	items  []interface{}
+	buffer int
+}
+
+//NewSetSequence returns a new SetSequence holding the unique items of data
+func NewSetSequence(data []interface{}, buff int) *SetSequence {
+	s := &SetSequence{
+		NewBaseSequence(buff, nil),
+		make(map[interface{}]struct{}),
+		make([]interface{}, 0, len(data)),
+		buff,
+	}
+
+	s.add(data...)
+	return s
+}
+
+//add stores the items not yet in the set, the caller must hold the lock
+func (s *SetSequence) add(f ...interface{}) {
+	for _, v := range f {
+		if _, ok := s.index[v]; ok {
+			continue
+		}
+
+		s.index[v] = struct{}{}
+		s.items = append(s.items, v)
+	}
+}
+
+//del removes the items from the set, the caller must hold the lock
+func (s *SetSequence) del(f ...interface{}) {
+	for _, v := range f {
+		if _, ok := s.index[v]; !ok {
+			continue
+		}
+
+		delete(s.index, v)
+
+		for i, item := range s.items {
+			if item == v {
+				s.items = append(s.items[:i], s.items[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+//keep removes every item the function rejects, the caller must hold the lock
+func (s *SetSequence) keep(fn func(interface{}) bool) {
+	items := s.items[:0]
+
+	for _, v := range s.items {
+		if fn(v) {
+			items = append(items, v)
+			continue
+		}
+
+		delete(s.index, v)
+	}
+
+	for i := len(items); i < len(s.items); i++ {
+		s.items[i] = nil
+	}
+
+	s.items = items
+}
+
+//Mutate allows mutation on a copy of the set items, duplicates in the
+//returned list are dropped
+func (s *SetSequence) Mutate(fn MutFunc) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	items := make([]interface{}, len(s.items))
+	copy(items, s.items)
+
+	if res, ok := fn(items).([]interface{}); ok {
+		s.index = make(map[interface{}]struct{})
+		s.items = make([]interface{}, 0, len(res))
+		s.add(res...)
+	}
+}
+
+//Iterator returns an iterator over a snapshot of the set, keyed by position
+func (s *SetSequence) Iterator() Iterable {
+	return NewListIterator(s.Obj())
+}
+
+//Parent returns the sequence as a sequencable
+func (s *SetSequence) Parent() Sequencable {
+	return Sequencable(s)
+}
+
+//Obj returns a copy of the set items in the order they were added
+func (s *SetSequence) Obj() []interface{} {
+	s.lock.RLock()
+	items := make([]interface{}, len(s.items))
+	copy(items, s.items)
+	s.lock.RUnlock()
+	return items
+}
+
+//Length returns length of data
+func (s *SetSequence) Length() int {
+	s.lock.RLock()
+	sz := len(s.items)
+	s.lock.RUnlock()
+	return sz
+}
+
+//Has reports if the item is in the set
+func (s *SetSequence) Has(v interface{}) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	_, ok := s.index[v]
+	return ok
+}
+
+//Add stores every item that is not already in the set
+func (s *SetSequence) Add(f ...interface{}) SetSequencable {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.add(f...)
+	return s
+}
+
+//Delete removes the items from the set
+func (s *SetSequence) Delete(f ...interface{}) SetSequencable {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.del(f...)
+	return s
+}
+
+//Clear wipes internal structure data
+func (s *SetSequence) Clear() SetSequencable {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.index = make(map[interface{}]struct{})
+	s.items = make([]interface{}, 0)
+	return s
+}
+
+//Clone copies internal structure data
+func (s *SetSequence) Clone() SetSequencable {
+	return NewSetSequence(s.Obj(), s.buffer)
+}
+
+//memberSet collects the items of the sets into a lookup table
+func memberSet(sets []SetSequencable) map[interface{}]int {
+	members := make(map[interface{}]int)
+
+	for _, set := range sets {
+		for _, v := range set.Obj() {
+			members[v]++
+		}
+	}
+
+	return members
+}
+
+//Union adds the items of every other set to this one in place
+func (s *SetSequence) Union(others ...SetSequencable) SetSequencable {
+	var items []interface{}
+
+	for _, o := range others {
+		items = append(items, o.Obj()...)
+	}
+
+	return s.Add(items...)
+}
+
+//Intersect keeps only the items found in every other set in place
+func (s *SetSequence) Intersect(others ...SetSequencable) SetSequencable {
+	sets := make([]map[interface{}]int, len(others))
+
+	for i, o := range others {
+		sets[i] = memberSet([]SetSequencable{o})
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.keep(func(v interface{}) bool {
+		for _, set := range sets {
+			if set[v] == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	return s
+}
+
+//Difference removes the items found in any other set in place
+func (s *SetSequence) Difference(others ...SetSequencable) SetSequencable {
+	members := memberSet(others)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.keep(func(v interface{}) bool {
+		return members[v] == 0
+	})
+	return s
+}
+
+//SymmetricDifference keeps the items found in an odd number of the sets,
+//this one included, in place. With a single other set that is the items in
+//exactly one of the two
+func (s *SetSequence) SymmetricDifference(others ...SetSequencable) SetSequencable {
+	members := memberSet(others)
+
+	var extra []interface{}
+	seen := make(map[interface{}]bool)
+
+	for _, o := range others {
+		for _, v := range o.Obj() {
+			if !seen[v] {
+				seen[v] = true
+				extra = append(extra, v)
+			}
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	own := make(map[interface{}]bool, len(s.items))
+	for _, v := range s.items {
+		own[v] = true
+	}
+
+	s.keep(func(v interface{}) bool {
+		return members[v]%2 == 0
+	})
+
+	for _, v := range extra {
+		if !own[v] && members[v]%2 == 1 {
+			s.add(v)
+		}
+	}
+	return s
+}
+
+const (
+	setUnion = iota
+	setIntersect
+	setDifference
+	setSymmetric
+)
+
+//SetOpIterator lazily yields the distinct values of a set operation over two
+//iterators, keyed by position. A side read in full up front is buffered, so
+//one-pass sources like FromChan work too
+type SetOpIterator struct {
+	left  Iterable
+	right Iterable
+	lcur  Iterable
+	rcur  Iterable
+	op    int
+	seen  map[interface{}]bool
+	lset  map[interface{}]bool
+	rset  map[interface{}]bool
+	phase int
+	value interface{}
+	index int
+}
+
+func newSetOp(a, b Iterable, op int) *SetOpIterator {
+	return &SetOpIterator{
+		left:  a.Clone(),
+		right: b.Clone(),
+		op:    op,
+		index: -1,
+	}
+}
+
+//UnionOf returns an iterator over the distinct values of a followed by those
+//of b not seen in a
+func UnionOf(a, b Iterable) *SetOpIterator {
+	return newSetOp(a, b, setUnion)
+}
+
+//IntersectOf returns an iterator over the distinct values of a that are also
+//in b, b is read in full on the first call to Next
+func IntersectOf(a, b Iterable) *SetOpIterator {
+	return newSetOp(a, b, setIntersect)
+}
+
+//DifferenceOf returns an iterator over the distinct values of a that are not
+//in b, b is read in full on the first call to Next
+func DifferenceOf(a, b Iterable) *SetOpIterator {
+	return newSetOp(a, b, setDifference)
+}
+
+//SymmetricDifferenceOf returns an iterator over the distinct values in only
+//one of a and b, both are read in full on the first call to Next
+func SymmetricDifferenceOf(a, b Iterable) *SetOpIterator {
+	return newSetOp(a, b, setSymmetric)
+}
+
+//valueSet reads the values of the iterable into a lookup table and returns
+//an iterator over the values read, so the iterable is only walked once
+func valueSet(it Iterable) (map[interface{}]bool, Iterable, error) {
+	set := make(map[interface{}]bool)
+	var values []interface{}
+
+	err := each(it, func(f Iterable) (bool, error) {
+		set[f.Value()] = true
+		values = append(values, f.Value())
+		return true, nil
+	})
+
+	return set, NewListIterator(values), err
+}
+
+//prepare builds the lookup tables the operation needs
+func (s *SetOpIterator) prepare() error {
+	s.seen = make(map[interface{}]bool)
+	s.lcur, s.rcur = s.left, s.right
+
+	if s.op == setUnion {
+		return nil
+	}
+
+	set, values, err := valueSet(s.right)
+
+	if err != nil {
+		return err
+	}
+
+	s.rset, s.rcur = set, values
+
+	if s.op == setSymmetric {
+		set, values, err = valueSet(s.left)
+
+		if err != nil {
+			return err
+		}
+
+		s.lset, s.lcur = set, values
+	}
+
+	return nil
+}
+
+//accept reports if a value of the current side belongs in the result
+func (s *SetOpIterator) accept(v interface{}) bool {
+	if s.seen[v] {
+		return false
+	}
+
+	switch s.op {
+	case setIntersect:
+		return s.rset[v]
+	case setDifference:
+		return !s.rset[v]
+	case setSymmetric:
+		if s.phase == 0 {
+			return !s.rset[v]
+		}
+		return !s.lset[v]
+	}
+
+	return true
+}
+
+//Next moves to the next value of the result
+func (s *SetOpIterator) Next() error {
+	if s.seen == nil {
+		if err := s.prepare(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		it := s.lcur
+
+		if s.phase == 1 {
+			it = s.rcur
+		}
+
+		err := it.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			if s.phase == 0 && (s.op == setUnion || s.op == setSymmetric) {
+				s.phase = 1
+				continue
+			}
+
+			s.value = nil
+			return ErrENDINDEX
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if v := it.Value(); s.accept(v) {
+			s.seen[v] = true
+			s.value = v
+			s.index++
+			return nil
+		}
+	}
+}
+
+//Reset reverst the iterators index
+func (s *SetOpIterator) Reset() {
+	s.left.Reset()
+	s.right.Reset()
+	s.seen = nil
+	s.lcur = nil
+	s.rcur = nil
+	s.lset = nil
+	s.rset = nil
+	s.phase = 0
+	s.value = nil
+	s.index = -1
+}
+
+//Stop releases the parent iterators if they hold resources
+func (s *SetOpIterator) Stop() {
+	stop(s.left)
+	stop(s.right)
+}
+
+//Key returns the current position of the iterator
+func (s *SetOpIterator) Key() interface{} {
+	if s.index < 0 {
+		return nil
+	}
+	return s.index
+}
+
+//Value returns the current value of the result
+func (s *SetOpIterator) Value() interface{} {
+	return s.value
+}
+
+//Length returns UNKNOWNLENGTH as the result size is only known after
+//iteration
+func (s *SetOpIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (s *SetOpIterator) Clone() Iterable {
+	return newSetOp(s.left, s.right, s.op)
+}
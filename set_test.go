@@ -0,0 +1,137 @@
+package sequence
+
+import "testing"
+
+func TestSetSequence(t *testing.T) {
+	var set SetSequencable = NewSetSequence([]interface{}{3, 1, 3, 2}, 0)
+
+	if set.Length() != 3 || !sameValues(set.Obj(), []interface{}{3, 1, 2}) {
+		t.Fatal("set should keep unique items in order", set.Obj())
+	}
+
+	set.Add(1, 4).Delete(3)
+
+	if set.Has(3) || !set.Has(4) || !sameValues(set.Obj(), []interface{}{1, 2, 4}) {
+		t.Fatal("set add and delete are incorrect", set.Obj())
+	}
+
+	if res := collect(set.Iterator()); !sameValues(res, []interface{}{1, 2, 4}) {
+		t.Fatal("set iterator is incorrect", res)
+	}
+
+	cl := set.Clone()
+	cl.Add(9)
+
+	if set.Has(9) {
+		t.Fatal("clone should not share data with its source")
+	}
+
+	set.Mutate(func(f interface{}) interface{} {
+		return append(f.([]interface{}), 1, 5)
+	})
+
+	if !sameValues(set.Obj(), []interface{}{1, 2, 4, 5}) {
+		t.Fatal("mutate should drop duplicates", set.Obj())
+	}
+
+	if set.Clear().Length() != 0 || set.Has(1) {
+		t.Fatal("clear should empty the set")
+	}
+}
+
+func TestSetUnhashableReleasesLock(t *testing.T) {
+	set := NewSetSequence([]interface{}{1}, 0)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("adding an unhashable item should panic")
+			}
+		}()
+		set.Add([]int{1})
+	}()
+
+	released(t, set.Sequence)
+
+	if set.Add(2).Length() != 2 {
+		t.Fatal("set should stay usable after a failed add", set.Obj())
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	a := func() SetSequencable { return NewSetSequence([]interface{}{1, 2, 3, 4}, 0) }
+	b := NewSetSequence([]interface{}{3, 4, 5}, 0)
+
+	if res := a().Union(b).Obj(); !sameValues(res, []interface{}{1, 2, 3, 4, 5}) {
+		t.Fatal("union is incorrect", res)
+	}
+
+	if res := a().Intersect(b).Obj(); !sameValues(res, []interface{}{3, 4}) {
+		t.Fatal("intersect is incorrect", res)
+	}
+
+	if res := a().Difference(b).Obj(); !sameValues(res, []interface{}{1, 2}) {
+		t.Fatal("difference is incorrect", res)
+	}
+
+	if res := a().SymmetricDifference(b).Obj(); !sameValues(res, []interface{}{1, 2, 5}) {
+		t.Fatal("symmetric difference is incorrect", res)
+	}
+
+	self := a()
+	if res := self.Union(self).Obj(); len(res) != 4 {
+		t.Fatal("union with itself should change nothing", res)
+	}
+}
+
+func TestLazySetAlgebra(t *testing.T) {
+	a := NewListIterator([]interface{}{1, 2, 2, 3, 4})
+	b := NewListIterator([]interface{}{3, 4, 5, 5})
+
+	if res := collect(UnionOf(a, b)); !sameValues(res, []interface{}{1, 2, 3, 4, 5}) {
+		t.Fatal("lazy union is incorrect", res)
+	}
+
+	if res := collect(IntersectOf(a, b)); !sameValues(res, []interface{}{3, 4}) {
+		t.Fatal("lazy intersect is incorrect", res)
+	}
+
+	if res := collect(DifferenceOf(a, b)); !sameValues(res, []interface{}{1, 2}) {
+		t.Fatal("lazy difference is incorrect", res)
+	}
+
+	sd := SymmetricDifferenceOf(a, b)
+
+	if res := collect(sd); !sameValues(res, []interface{}{1, 2, 5}) {
+		t.Fatal("lazy symmetric difference is incorrect", res)
+	}
+
+	sd.Reset()
+
+	if res := collect(sd); !sameValues(res, []interface{}{1, 2, 5}) {
+		t.Fatal("reset symmetric difference should start over", res)
+	}
+
+	lazy := Take(UnionOf(counter(), b), 3)
+
+	if res := collect(lazy); !sameValues(res, []interface{}{0, 1, 2}) {
+		t.Fatal("lazy union should work over a generator", res)
+	}
+
+	chans := func(values ...int) *ChanIterator {
+		ch := make(chan int, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		close(ch)
+		return FromChan(ch)
+	}
+
+	if res := collect(IntersectOf(chans(1, 2, 3), chans(2, 3, 4))); !sameValues(res, []interface{}{2, 3}) {
+		t.Fatal("lazy intersect should read a one-pass source once", res)
+	}
+
+	if res := collect(SymmetricDifferenceOf(chans(1, 2, 3), chans(2, 3, 4))); !sameValues(res, []interface{}{1, 4}) {
+		t.Fatal("lazy symmetric difference should buffer one-pass sources", res)
+	}
+}
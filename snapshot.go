@@ -0,0 +1,189 @@
+package sequence
+
+//own copies the data if a snapshot of it was handed out, so writes never
+//reach a snapshot. The caller must hold the lock
+func (l *ListSequence) own() {
+	if !l.shared.Load() {
+		return
+	}
+
+	nd := make([]interface{}, len(l.data), cap(l.data))
+	copy(nd, l.data)
+	l.data = nd
+	l.shared.Store(false)
+}
+
+//own copies the data if a snapshot of it was handed out, so writes never
+//reach a snapshot. The caller must hold the lock
+func (l *MapSequence) own() {
+	if !l.shared.Load() {
+		return
+	}
+
+	nd := make(map[interface{}]interface{}, len(l.data))
+
+	for k, v := range l.data {
+		nd[k] = v
+	}
+
+	l.data = nd
+	l.shared.Store(false)
+}
+
+//snapshot hands out the data and the order to walk it in, an insertion order
+//is fixed at the time of the snapshot
+func (l *MapSequence) snapshot() (map[interface{}]interface{}, KeyOrder) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	l.shared.Store(true)
+
+	if l.tracked() {
+		return l.data, fixedOrder(l.orderedKeys(l.data))
+	}
+
+	return l.data, l.order
+}
+
+//fixedOrder returns a KeyOrder that always gives the same keys
+func fixedOrder(keys []interface{}) KeyOrder {
+	return func(map[interface{}]interface{}) []interface{} {
+		return keys
+	}
+}
+
+//LiveIterator returns an iterator reading the sequence as it is at each call
+//to Next instead of a snapshot. Every read takes the read lock, so it is safe
+//alongside writers, but it walks positions not items: items added at the end
+//before it gets there are visited, while deleting ahead of or behind its
+//position shifts the rest, so an item can be skipped or seen twice
+func (l *ListSequence) LiveIterator() Iterable {
+	return &LiveListIterator{l, nil, -1}
+}
+
+//LiveListIterator walks the positions of a ListSequence while it is written
+//to, see ListSequence.LiveIterator
+type LiveListIterator struct {
+	seq   *ListSequence
+	value interface{}
+	index int
+}
+
+//Next moves to the next position if the sequence still reaches it
+func (l *LiveListIterator) Next() error {
+	l.seq.lock.RLock()
+	defer l.seq.lock.RUnlock()
+
+	i := l.index + 1
+
+	if i >= len(l.seq.data) {
+		l.value = nil
+		return ErrENDINDEX
+	}
+
+	l.index = i
+	l.value = l.seq.data[i]
+	return nil
+}
+
+//Reset reverst the iterators index
+func (l *LiveListIterator) Reset() {
+	l.value = nil
+	l.index = -1
+}
+
+//Key returns the current index of the iterator
+func (l *LiveListIterator) Key() interface{} {
+	return l.index
+}
+
+//Value returns the item read at the current index
+func (l *LiveListIterator) Value() interface{} {
+	return l.value
+}
+
+//Length returns the current length of the sequence
+func (l *LiveListIterator) Length() int {
+	return l.seq.Length()
+}
+
+//Clone returns a new iterator off that data
+func (l *LiveListIterator) Clone() Iterable {
+	return l.seq.LiveIterator()
+}
+
+//LiveIterator returns an iterator reading the sequence values as they are at
+//each call to Next instead of a snapshot. It walks the keys present when it
+//starts or is Reset, in the sequence order, skipping those deleted since and
+//never visiting keys added after. Every read takes the read lock, so it is
+//safe alongside writers
+func (l *MapSequence) LiveIterator() Iterable {
+	return &LiveMapIterator{l, nil, 0, nil, nil}
+}
+
+//LiveMapIterator walks the keys of a MapSequence while it is written to, see
+//MapSequence.LiveIterator
+type LiveMapIterator struct {
+	seq   *MapSequence
+	keys  []interface{}
+	pos   int
+	key   interface{}
+	value interface{}
+}
+
+//Next moves to the next key still in the sequence
+func (l *LiveMapIterator) Next() error {
+	l.seq.lock.RLock()
+	defer l.seq.lock.RUnlock()
+
+	if l.keys == nil {
+		if l.seq.tracked() {
+			l.keys = l.seq.orderedKeys(l.seq.data)
+		} else {
+			l.keys = l.seq.order(l.seq.data)
+		}
+	}
+
+	for l.pos < len(l.keys) {
+		k := l.keys[l.pos]
+		l.pos++
+
+		if v, ok := l.seq.data[k]; ok {
+			l.key = k
+			l.value = v
+			return nil
+		}
+	}
+
+	l.key = nil
+	l.value = nil
+	return ErrENDINDEX
+}
+
+//Reset restarts the walk over the keys present at the next call to Next
+func (l *LiveMapIterator) Reset() {
+	l.keys = nil
+	l.pos = 0
+	l.key = nil
+	l.value = nil
+}
+
+//Key returns the current key of the iterator
+func (l *LiveMapIterator) Key() interface{} {
+	return l.key
+}
+
+//Value returns the value read for the current key
+func (l *LiveMapIterator) Value() interface{} {
+	return l.value
+}
+
+//Length returns the current length of the sequence
+func (l *LiveMapIterator) Length() int {
+	return l.seq.Length()
+}
+
+//Clone returns a new iterator off that data
+func (l *LiveMapIterator) Clone() Iterable {
+	return l.seq.LiveIterator()
+}
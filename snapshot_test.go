@@ -0,0 +1,130 @@
+package sequence
+
+import "sync"
+import "testing"
+
+func TestListSnapshotIterator(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2, 3}, 0)
+	it := ls.Iterator()
+
+	ls.Add(4)
+	ls.Delete(0)
+	ls.Sort(func(a, b interface{}) bool { return a.(int) > b.(int) })
+
+	if res := collect(it); !sameValues(res, []interface{}{1, 2, 3}) {
+		t.Fatal("snapshot should not see later writes", res)
+	}
+
+	if !sameValues(ls.Obj(), []interface{}{4, 3, 2}) {
+		t.Fatal("writes after a snapshot are incorrect", ls.Obj())
+	}
+
+	obj := ls.Obj()
+	ls.Clear()
+
+	if len(obj) != 3 || ls.Length() != 0 {
+		t.Fatal("clear should not reach a handed out snapshot", obj)
+	}
+}
+
+func TestMapSnapshotIterator(t *testing.T) {
+	ms := NewOrderedMapSequence(nil, 0)
+	ms.Add("a", 1)
+	ms.Add("b", 2)
+
+	it := ms.Iterator()
+
+	ms.Add("c", 3)
+	ms.Delete("a")
+
+	if keys := keysOf(it); !sameValues(keys, []interface{}{"a", "b"}) {
+		t.Fatal("snapshot should not see later writes", keys)
+	}
+
+	if keys := keysOf(ms.Iterator()); !sameValues(keys, []interface{}{"b", "c"}) {
+		t.Fatal("new snapshot should see the writes", keys)
+	}
+}
+
+func TestLiveIterators(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2}, 0)
+	live := ls.LiveIterator()
+
+	live.Next()
+	ls.Add(3)
+
+	if res := collect(live); !sameValues(res, []interface{}{2, 3}) {
+		t.Fatal("live list iterator should see items added ahead of it", res)
+	}
+
+	ms := NewOrderedMapSequence(nil, 0)
+	ms.Add("a", 1)
+	ms.Add("b", 2)
+	ms.Add("c", 3)
+
+	mlive := ms.LiveIterator()
+	mlive.Next()
+
+	ms.Delete("b")
+	ms.Add("c", 30)
+	ms.Add("d", 4)
+
+	if res := collect(mlive); !sameValues(res, []interface{}{30}) {
+		t.Fatal("live map iterator should skip deleted keys and read new values", res)
+	}
+
+	mlive.Reset()
+
+	if keys := keysOf(mlive); !sameValues(keys, []interface{}{"a", "c", "d"}) {
+		t.Fatal("reset live map iterator should take the keys again", keys)
+	}
+}
+
+func TestSnapshotConcurrentWriters(t *testing.T) {
+	ls := NewListSequence(nil, 0)
+	ms := NewOrderedMapSequence(nil, 0)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				ls.Add(i)
+				ms.Add(g*1000+i, i)
+
+				if i%10 == 0 {
+					ls.Delete(0)
+					ms.Delete(g*1000 + i)
+				}
+			}
+		}(g)
+	}
+
+	for r := 0; r < 50; r++ {
+		it := ls.Iterator()
+		size := it.Length()
+
+		if n := len(collect(it)); n != size {
+			t.Fatal("list snapshot changed while iterating", n, size)
+		}
+
+		mit := ms.Iterator()
+		msize := mit.Length()
+
+		if n := len(collect(mit)); n != msize {
+			t.Fatal("map snapshot changed while iterating", n, msize)
+		}
+
+		collect(ls.LiveIterator())
+		collect(ms.LiveIterator())
+		ls.Clone()
+		ms.Clone()
+	}
+
+	wg.Wait()
+
+	if ls.Length() != 4*180 || ms.Length() != 4*180 {
+		t.Fatal("concurrent writes were lost", ls.Length(), ms.Length())
+	}
+}
@@ -0,0 +1,396 @@
+package sequence
+
+import "bufio"
+import "encoding/gob"
+import "errors"
+import "io"
+import "os"
+import "reflect"
+import "sort"
+
+//NaturalLess orders ints, uints, floats and strings by their natural order,
+//numbers of different kinds are compared as float64. nil comes before every
+//other value and values of other kinds are never less than anything
+func NaturalLess(a, b interface{}) bool {
+	av := reflect.ValueOf(a)
+	bv := reflect.ValueOf(b)
+
+	if !av.IsValid() || !bv.IsValid() {
+		return !av.IsValid() && bv.IsValid()
+	}
+
+	switch {
+	case av.CanInt() && bv.CanInt():
+		return av.Int() < bv.Int()
+	case av.CanUint() && bv.CanUint():
+		return av.Uint() < bv.Uint()
+	case isNumber(av) && isNumber(bv):
+		return toFloat(av) < toFloat(bv)
+	case av.Kind() == reflect.String && bv.Kind() == reflect.String:
+		return av.String() < bv.String()
+	}
+
+	return false
+}
+
+func isNumber(v reflect.Value) bool {
+	return v.CanInt() || v.CanUint() || v.CanFloat()
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+//Sort orders the sequence data in place with the less function
+func (l *ListSequence) Sort(less LessFunc) ListSequencable {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	old := l.before()
+	l.own()
+	sort.Slice(l.data, func(i, j int) bool {
+		return less(l.data[i], l.data[j])
+	})
+	l.mutated(old)
+	return l
+}
+
+//SortStable orders the sequence data in place with the less function, keeping
+//the original order of equal items
+func (l *ListSequence) SortStable(less LessFunc) ListSequencable {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	old := l.before()
+	l.own()
+	sort.SliceStable(l.data, func(i, j int) bool {
+		return less(l.data[i], l.data[j])
+	})
+	l.mutated(old)
+	return l
+}
+
+//SortBy stable sorts the sequence data in place by the NaturalLess order of
+//the key the function gives each item, the key is computed once per item
+func (l *ListSequence) SortBy(fn func(interface{}) interface{}) ListSequencable {
+	l.lock.Lock()
+	defer l.unlockAndNotify()
+
+	old := l.before()
+	l.own()
+	keys := make([]interface{}, len(l.data))
+
+	for i, v := range l.data {
+		keys[i] = fn(v)
+	}
+
+	sort.Stable(keyedSort{keys, l.data})
+	l.mutated(old)
+	return l
+}
+
+//keyedSort sorts a list by a parallel list of keys
+type keyedSort struct {
+	keys []interface{}
+	data []interface{}
+}
+
+func (k keyedSort) Len() int {
+	return len(k.data)
+}
+
+func (k keyedSort) Less(i, j int) bool {
+	return NaturalLess(k.keys[i], k.keys[j])
+}
+
+func (k keyedSort) Swap(i, j int) {
+	k.keys[i], k.keys[j] = k.keys[j], k.keys[i]
+	k.data[i], k.data[j] = k.data[j], k.data[i]
+}
+
+//sortEntry is a key and value pair being sorted, exported fields let it go
+//through encoding/gob when spilled to disk
+type sortEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+//sortRun is a sorted run of entries read one at a time
+type sortRun interface {
+	next() (sortEntry, bool, error)
+	close()
+}
+
+//memRun is a sorted run kept in memory
+type memRun struct {
+	entries []sortEntry
+}
+
+func (m *memRun) next() (sortEntry, bool, error) {
+	if len(m.entries) == 0 {
+		return sortEntry{}, false, nil
+	}
+
+	e := m.entries[0]
+	m.entries = m.entries[1:]
+	return e, true, nil
+}
+
+func (m *memRun) close() {}
+
+//fileRun is a sorted run spilled to a temporary file
+type fileRun struct {
+	file *os.File
+	dec  *gob.Decoder
+}
+
+func spill(entries []sortEntry) (*fileRun, error) {
+	f, err := os.CreateTemp("", "sequence-sort-*")
+
+	if err != nil {
+		return nil, err
+	}
+
+	run := &fileRun{file: f}
+	w := bufio.NewWriter(f)
+	enc := gob.NewEncoder(w)
+
+	for _, e := range entries {
+		if err := enc.Encode(&e); err != nil {
+			run.close()
+			return nil, err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		run.close()
+		return nil, err
+	}
+
+	if _, err := f.Seek(0, 0); err != nil {
+		run.close()
+		return nil, err
+	}
+
+	run.dec = gob.NewDecoder(bufio.NewReader(f))
+	return run, nil
+}
+
+func (r *fileRun) next() (sortEntry, bool, error) {
+	var e sortEntry
+
+	if err := r.dec.Decode(&e); err != nil {
+		if err == io.EOF {
+			return e, false, nil
+		}
+		return e, false, err
+	}
+
+	return e, true, nil
+}
+
+func (r *fileRun) close() {
+	r.file.Close()
+	os.Remove(r.file.Name())
+}
+
+//SortedIterator yields the items of its parent ordered by value
+type SortedIterator struct {
+	parent  Iterable
+	less    LessFunc
+	budget  int
+	runs    []sortRun
+	heads   []*sortEntry
+	started bool
+	current sortEntry
+	err     error
+}
+
+//Sorted returns an iterator yielding the items of the iterable stable sorted
+//by value with the less function, keeping the keys they had. The iterable is
+//read in full on the first call to Next
+func Sorted(it Iterable, less LessFunc) *SortedIterator {
+	return SortedExternal(it, less, 0)
+}
+
+//SortedExternal is Sorted that keeps at most budget items in memory, sorting
+//larger inputs in runs spilled to temporary files through encoding/gob and
+//merging them back. Keys and values of custom types must be registered with
+//gob.Register. A budget below 1 keeps everything in memory
+func SortedExternal(it Iterable, less LessFunc, budget int) *SortedIterator {
+	return &SortedIterator{
+		parent: it.Clone(),
+		less:   less,
+		budget: budget,
+	}
+}
+
+//sortEntries stable sorts the entries by value
+func (s *SortedIterator) sortEntries(entries []sortEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return s.less(entries[i].Value, entries[j].Value)
+	})
+}
+
+//load reads the parent into sorted runs, spilling each full run to disk when
+//a budget is set
+func (s *SortedIterator) load() error {
+	var entries []sortEntry
+
+	for {
+		err := s.parent.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, sortEntry{s.parent.Key(), s.parent.Value()})
+
+		if s.budget > 0 && len(entries) >= s.budget {
+			s.sortEntries(entries)
+			run, err := spill(entries)
+
+			if err != nil {
+				return err
+			}
+
+			s.runs = append(s.runs, run)
+			entries = nil
+		}
+	}
+
+	s.sortEntries(entries)
+	s.runs = append(s.runs, &memRun{entries})
+	s.heads = make([]*sortEntry, len(s.runs))
+
+	for i, run := range s.runs {
+		if err := s.advance(i, run); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//advance moves the head of a run to its next entry
+func (s *SortedIterator) advance(i int, run sortRun) error {
+	e, ok, err := run.next()
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		s.heads[i] = nil
+		return nil
+	}
+
+	s.heads[i] = &e
+	return nil
+}
+
+//Next moves to the next item in sorted order
+func (s *SortedIterator) Next() error {
+	if s.err != nil {
+		return s.err
+	}
+
+	if !s.started {
+		s.started = true
+
+		if err := s.load(); err != nil {
+			s.fail(err)
+			return err
+		}
+	}
+
+	best := -1
+
+	for i, h := range s.heads {
+		if h != nil && (best < 0 || s.less(h.Value, s.heads[best].Value)) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		s.fail(ErrENDINDEX)
+		return ErrENDINDEX
+	}
+
+	s.current = *s.heads[best]
+
+	if err := s.advance(best, s.runs[best]); err != nil {
+		s.fail(err)
+		return err
+	}
+
+	return nil
+}
+
+//fail records the error the iterator ended with and releases its runs
+func (s *SortedIterator) fail(err error) {
+	s.err = err
+	s.release()
+}
+
+//release closes and removes any spilled runs
+func (s *SortedIterator) release() {
+	for _, run := range s.runs {
+		run.close()
+	}
+
+	s.runs = nil
+	s.heads = nil
+}
+
+//Stop removes any temporary files and releases the parent, later calls to
+//Next return ErrENDINDEX until Reset is called
+func (s *SortedIterator) Stop() {
+	if s.err == nil {
+		s.err = ErrENDINDEX
+	}
+	s.release()
+	stop(s.parent)
+}
+
+//Reset reverst the iterators index, the parent is read again on the next
+//call to Next
+func (s *SortedIterator) Reset() {
+	s.release()
+	s.parent.Reset()
+	s.started = false
+	s.current = sortEntry{}
+	s.err = nil
+}
+
+//Key returns the key the current item had in the parent
+func (s *SortedIterator) Key() interface{} {
+	return s.current.Key
+}
+
+//Value returns the value of the current item
+func (s *SortedIterator) Value() interface{} {
+	return s.current.Value
+}
+
+//Length returns the parent iterators length or UNKNOWNLENGTH if the parent can
+//not tell
+func (s *SortedIterator) Length() int {
+	n, _ := sizeOf(s.parent)
+	return n
+}
+
+//Clone returns a new iterator off that data
+func (s *SortedIterator) Clone() Iterable {
+	return SortedExternal(s.parent, s.less, s.budget)
+}
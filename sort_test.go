@@ -0,0 +1,135 @@
+package sequence
+
+import "os"
+import "path/filepath"
+import "testing"
+
+func TestNaturalLess(t *testing.T) {
+	if !NaturalLess(1, 2) || NaturalLess(2, 1) {
+		t.Fatal("ints should compare naturally")
+	}
+
+	if !NaturalLess(1, 1.5) || !NaturalLess(uint8(1), 2) {
+		t.Fatal("mixed numbers should compare as floats")
+	}
+
+	if !NaturalLess("a", "b") || NaturalLess("a", 1) {
+		t.Fatal("strings should compare naturally and never against numbers")
+	}
+
+	if !NaturalLess(nil, 0) || NaturalLess(0, nil) {
+		t.Fatal("nil should come first")
+	}
+}
+
+func TestListSequenceSort(t *testing.T) {
+	ls := NewListSequence([]interface{}{5, 3, 9, 1}, 0)
+	ls.Sort(intLess)
+
+	if !sameValues(ls.Obj(), []interface{}{1, 3, 5, 9}) {
+		t.Fatal("sorted list is incorrect", ls.Obj())
+	}
+
+	words := NewListSequence([]interface{}{"bb", "a", "cc", "d"}, 0)
+	words.SortBy(func(v interface{}) interface{} {
+		return len(v.(string))
+	})
+
+	if !sameValues(words.Obj(), []interface{}{"a", "d", "bb", "cc"}) {
+		t.Fatal("list sorted by key should be stable", words.Obj())
+	}
+
+	words.SortStable(func(a, b interface{}) bool {
+		return a.(string)[0] > b.(string)[0]
+	})
+
+	if !sameValues(words.Obj(), []interface{}{"d", "cc", "bb", "a"}) {
+		t.Fatal("stable sorted list is incorrect", words.Obj())
+	}
+}
+
+func TestSortPanicReleasesLock(t *testing.T) {
+	mixed := NewListSequence([]interface{}{1, "a", 2}, 0)
+	strict := func(a, b interface{}) bool {
+		return a.(int) < b.(int)
+	}
+
+	for _, sorter := range []func(){
+		func() { mixed.Sort(strict) },
+		func() { mixed.SortStable(strict) },
+		func() { mixed.SortBy(func(v interface{}) interface{} { return v.(int) }) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("sorting a mixed list with an int order should panic")
+				}
+			}()
+			sorter()
+		}()
+
+		released(t, mixed.Sequence)
+	}
+
+	if mixed.Add(3); mixed.Length() != 4 {
+		t.Fatal("the list should stay writable after a failed sort", mixed.Obj())
+	}
+}
+
+func TestSorted(t *testing.T) {
+	s := Sorted(NewListIterator(data), intLess)
+
+	if s.Length() != len(data) {
+		t.Fatal("sorted length should equal its source", s.Length())
+	}
+
+	if keys := keysOf(s); !sameValues(keys, []interface{}{0, 3, 1, 2}) {
+		t.Fatal("sorted items should keep their keys", keys)
+	}
+
+	s.Reset()
+
+	if res := collect(s); !sameValues(res, []interface{}{1, 7, 32, 56}) {
+		t.Fatal("sorted values are incorrect", res)
+	}
+
+	if res := collect(Sorted(Take(counter(), 0), intLess)); len(res) != 0 {
+		t.Fatal("sorting nothing should yield nothing", res)
+	}
+}
+
+func TestSortedExternal(t *testing.T) {
+	items := make([]interface{}, 100)
+	for i := range items {
+		items[i] = (i * 37) % 100
+	}
+
+	before, _ := filepath.Glob(filepath.Join(os.TempDir(), "sequence-sort-*"))
+
+	s := SortedExternal(NewListIterator(items), intLess, 7)
+	res := collect(s)
+
+	if len(res) != 100 {
+		t.Fatal("external sort lost items", len(res))
+	}
+
+	for i, v := range res {
+		if v != i {
+			t.Fatal("external sort is out of order", i, v)
+		}
+	}
+
+	s.Reset()
+
+	if v, err := First(s); err != nil || v != 0 {
+		t.Fatal("reset external sort should start over", v, err)
+	}
+
+	s.Stop()
+
+	after, _ := filepath.Glob(filepath.Join(os.TempDir(), "sequence-sort-*"))
+
+	if len(after) != len(before) {
+		t.Fatal("external sort should remove its spilled runs", before, after)
+	}
+}
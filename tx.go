@@ -0,0 +1,186 @@
+package sequence
+
+//ListTx batches writes to a ListSequence so they are applied together.
+//Reads through the transaction see its own writes on top of the data as it
+//was at Begin. A transaction is meant for use by a single goroutine
+type ListTx struct {
+	seq  *ListSequence
+	work *ListSequence
+	log  []func()
+	done bool
+}
+
+//Begin starts a transaction over the sequence
+func (l *ListSequence) Begin() *ListTx {
+	work := NewListSequence(l.Obj(), l.buffer)
+	work.shared.Store(true)
+	return &ListTx{l, work, nil, false}
+}
+
+//Add queues the items to be appended and adds them to the transaction view
+func (t *ListTx) Add(f ...interface{}) *ListTx {
+	if t.done {
+		return t
+	}
+
+	t.work.add(f...)
+	t.log = append(t.log, func() {
+		t.seq.add(f...)
+	})
+
+	return t
+}
+
+//Delete queues the removal of the items at each index in turn and removes
+//them from the transaction view. The indexes are applied as given on Commit,
+//so writes committed by others since Begin shift what they point at
+func (t *ListTx) Delete(f ...interface{}) *ListTx {
+	if t.done {
+		return t
+	}
+
+	t.work.del(f...)
+	t.log = append(t.log, func() {
+		t.seq.del(f...)
+	})
+
+	return t
+}
+
+//Get retrieves the value from the transaction view, nil if the key is not an
+//int in range
+func (t *ListTx) Get(d interface{}) interface{} {
+	i, ok := d.(int)
+
+	if !ok || i < 0 || i >= len(t.work.data) {
+		return nil
+	}
+
+	return t.work.data[i]
+}
+
+//Length returns length of the transaction view
+func (t *ListTx) Length() int {
+	return len(t.work.data)
+}
+
+//Commit applies every queued write under a single lock, so readers see
+//either none or all of them
+func (t *ListTx) Commit() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.done = true
+	log := t.log
+	t.log = nil
+
+	t.seq.lock.Lock()
+	defer t.seq.unlockAndNotify()
+
+	for _, op := range log {
+		op()
+	}
+
+	return nil
+}
+
+//Rollback discards every queued write
+func (t *ListTx) Rollback() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.done = true
+	t.log = nil
+	return nil
+}
+
+//MapTx batches writes to a MapSequence so they are applied together. Reads
+//through the transaction see its own writes on top of the data as it was at
+//Begin. A transaction is meant for use by a single goroutine
+type MapTx struct {
+	seq  *MapSequence
+	work *MapSequence
+	log  []func()
+	done bool
+}
+
+//Begin starts a transaction over the sequence
+func (l *MapSequence) Begin() *MapTx {
+	work := NewMapSequence(l.Obj(), l.buffer)
+	work.shared.Store(true)
+	return &MapTx{l, work, nil, false}
+}
+
+//Add queues the key and value pairs to be set and sets them in the
+//transaction view, an odd trailing key is ignored
+func (t *MapTx) Add(f ...interface{}) *MapTx {
+	if t.done {
+		return t
+	}
+
+	t.work.add(f...)
+	t.log = append(t.log, func() {
+		t.seq.add(f...)
+	})
+
+	return t
+}
+
+//Delete queues the removal of the keys and removes them from the
+//transaction view
+func (t *MapTx) Delete(f ...interface{}) *MapTx {
+	if t.done {
+		return t
+	}
+
+	t.work.del(f...)
+	t.log = append(t.log, func() {
+		t.seq.del(f...)
+	})
+
+	return t
+}
+
+//Get retrieves the value from the transaction view
+func (t *MapTx) Get(d interface{}) interface{} {
+	return t.work.data[d]
+}
+
+//Length returns length of the transaction view
+func (t *MapTx) Length() int {
+	return len(t.work.data)
+}
+
+//Commit applies every queued write under a single lock, so readers see
+//either none or all of them
+func (t *MapTx) Commit() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.done = true
+	log := t.log
+	t.log = nil
+
+	t.seq.lock.Lock()
+	defer t.seq.unlockAndNotify()
+
+	for _, op := range log {
+		op()
+	}
+
+	return nil
+}
+
+//Rollback discards every queued write
+func (t *MapTx) Rollback() error {
+	if t.done {
+		return ErrTxDone
+	}
+
+	t.done = true
+	t.log = nil
+	return nil
+}
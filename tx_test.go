@@ -0,0 +1,119 @@
+package sequence
+
+import "sync"
+import "testing"
+
+func TestListTx(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2}, 0)
+	tx := ls.Begin()
+
+	tx.Add(3).Delete(0)
+
+	if tx.Get(0) != 2 || tx.Get(1) != 3 || tx.Length() != 2 || tx.Get(5) != nil {
+		t.Fatal("transaction should read its own writes", tx.Get(0), tx.Get(1))
+	}
+
+	if !sameValues(ls.Obj(), []interface{}{1, 2}) {
+		t.Fatal("sequence should not see writes before commit", ls.Obj())
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal("commit failed", err)
+	}
+
+	if !sameValues(ls.Obj(), []interface{}{2, 3}) {
+		t.Fatal("commit should apply every write", ls.Obj())
+	}
+
+	if err := tx.Commit(); err != ErrTxDone {
+		t.Fatal("second commit should return ErrTxDone", err)
+	}
+
+	rb := ls.Begin()
+	rb.Add(9)
+
+	if err := rb.Rollback(); err != nil || ls.Length() != 2 {
+		t.Fatal("rollback should discard the writes", ls.Obj())
+	}
+
+	if err := rb.Rollback(); err != ErrTxDone {
+		t.Fatal("second rollback should return ErrTxDone", err)
+	}
+}
+
+func TestMapTx(t *testing.T) {
+	ms := NewMapSequence(map[interface{}]interface{}{"a": 1}, 0)
+
+	var changes []Change
+	ms.Subscribe(func(c Change) {
+		changes = append(changes, c)
+	})
+
+	tx := ms.Begin()
+	tx.Add("b", 2, "c", 3).Delete("a")
+
+	if tx.Get("a") != nil || tx.Get("c") != 3 || ms.Get("a") != 1 {
+		t.Fatal("transaction should read its own writes only", tx.Get("a"))
+	}
+
+	tx.Commit()
+
+	if ms.Length() != 2 || ms.Get("b") != 2 || ms.Get("a") != nil {
+		t.Fatal("commit should apply every write", ms.Obj())
+	}
+
+	if len(changes) != 3 {
+		t.Fatal("commit should notify every write", changes)
+	}
+}
+
+func TestTxAtomicCommit(t *testing.T) {
+	ls := NewListSequence(nil, 0)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			tx := ls.Begin()
+			tx.Add(i, i, i)
+			tx.Commit()
+		}
+	}()
+
+	for r := 0; r < 200; r++ {
+		if n := ls.Length(); n%3 != 0 {
+			t.Fatal("reader saw a partial batch", n)
+		}
+	}
+
+	wg.Wait()
+
+	if ls.Length() != 300 {
+		t.Fatal("committed writes were lost", ls.Length())
+	}
+}
+
+func TestTxCommitPanicReleasesLock(t *testing.T) {
+	ms := NewMapSequence(nil, 0)
+	tx := ms.Begin()
+	tx.Add("a", 1)
+	tx.log = append(tx.log, func() {
+		ms.add([]interface{}{1}, 2)
+	})
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("replaying an unhashable key should panic")
+			}
+		}()
+		tx.Commit()
+	}()
+
+	ms.Add("b", 2)
+
+	if ms.Get("a") != 1 || ms.Get("b") != 2 {
+		t.Fatal("sequence should stay writable after a failed commit", ms.Obj())
+	}
+}
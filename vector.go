@@ -0,0 +1,395 @@
+package sequence
+
+const (
+	vecBits  = 5
+	vecWidth = 1 << vecBits
+	vecMask  = vecWidth - 1
+)
+
+//vecNode is a node of the vector trie, branches hold *vecNode children and
+//leaves hold the values
+type vecNode struct {
+	items [vecWidth]interface{}
+}
+
+//copy returns a shallow copy of the node for path copying
+func (n *vecNode) copy() *vecNode {
+	c := *n
+	return &c
+}
+
+var emptyVecNode = &vecNode{}
+
+//PersistentList represents an immutable list on a 32 way vector trie, every
+//change returns a new version sharing all untouched nodes with the old one.
+//Versions are never written to, so they need no locking
+type PersistentList struct {
+	count int
+	shift uint
+	root  *vecNode
+	tail  []interface{}
+}
+
+var emptyList = &PersistentList{0, vecBits, emptyVecNode, nil}
+
+//NewPersistentList returns a new PersistentList holding data
+func NewPersistentList(data []interface{}) *PersistentList {
+	p := emptyList
+
+	for _, v := range data {
+		p = p.Conj(v)
+	}
+
+	return p
+}
+
+//tailOffset returns the index of the first item held in the tail
+func (p *PersistentList) tailOffset() int {
+	if p.count < vecWidth {
+		return 0
+	}
+	return ((p.count - 1) >> vecBits) << vecBits
+}
+
+//leafFor returns the leaf holding index i
+func (p *PersistentList) leafFor(i int) []interface{} {
+	if i >= p.tailOffset() {
+		return p.tail
+	}
+
+	node := p.root
+
+	for level := p.shift; level > 0; level -= vecBits {
+		node = node.items[(i>>level)&vecMask].(*vecNode)
+	}
+
+	return node.items[:]
+}
+
+//Nth returns the item at index i and reports if i was in range
+func (p *PersistentList) Nth(i int) (interface{}, bool) {
+	if i < 0 || i >= p.count {
+		return nil, false
+	}
+
+	return p.leafFor(i)[i&vecMask], true
+}
+
+//Conj returns a new version with v appended
+func (p *PersistentList) Conj(v interface{}) *PersistentList {
+	if p.count-p.tailOffset() < vecWidth {
+		tail := make([]interface{}, len(p.tail)+1)
+		copy(tail, p.tail)
+		tail[len(p.tail)] = v
+		return &PersistentList{p.count + 1, p.shift, p.root, tail}
+	}
+
+	leaf := &vecNode{}
+	copy(leaf.items[:], p.tail)
+
+	root := p.root
+	shift := p.shift
+
+	if (p.count >> vecBits) > (1 << p.shift) {
+		root = &vecNode{}
+		root.items[0] = p.root
+		root.items[1] = newVecPath(p.shift, leaf)
+		shift += vecBits
+	} else {
+		root = p.pushTail(p.shift, p.root, leaf)
+	}
+
+	return &PersistentList{p.count + 1, shift, root, []interface{}{v}}
+}
+
+//newVecPath builds a chain of branches down to the leaf
+func newVecPath(level uint, leaf *vecNode) *vecNode {
+	if level == 0 {
+		return leaf
+	}
+
+	n := &vecNode{}
+	n.items[0] = newVecPath(level-vecBits, leaf)
+	return n
+}
+
+//pushTail copies the path to the slot of the full tail and stores it there
+func (p *PersistentList) pushTail(level uint, parent, leaf *vecNode) *vecNode {
+	sub := ((p.count - 1) >> level) & vecMask
+	n := parent.copy()
+
+	if level == vecBits {
+		n.items[sub] = leaf
+		return n
+	}
+
+	if child, ok := parent.items[sub].(*vecNode); ok {
+		n.items[sub] = p.pushTail(level-vecBits, child, leaf)
+		return n
+	}
+
+	n.items[sub] = newVecPath(level-vecBits, leaf)
+	return n
+}
+
+//Assoc returns a new version with the item at index i replaced by v, i may
+//equal Length to append. It reports false and returns the same version if
+//i is out of range
+func (p *PersistentList) Assoc(i int, v interface{}) (*PersistentList, bool) {
+	if i == p.count {
+		return p.Conj(v), true
+	}
+
+	if i < 0 || i > p.count {
+		return p, false
+	}
+
+	if i >= p.tailOffset() {
+		tail := make([]interface{}, len(p.tail))
+		copy(tail, p.tail)
+		tail[i&vecMask] = v
+		return &PersistentList{p.count, p.shift, p.root, tail}, true
+	}
+
+	return &PersistentList{p.count, p.shift, assocVec(p.shift, p.root, i, v), p.tail}, true
+}
+
+//assocVec copies the path down to index i and replaces the item
+func assocVec(level uint, node *vecNode, i int, v interface{}) *vecNode {
+	n := node.copy()
+
+	if level == 0 {
+		n.items[i&vecMask] = v
+		return n
+	}
+
+	sub := (i >> level) & vecMask
+	n.items[sub] = assocVec(level-vecBits, node.items[sub].(*vecNode), i, v)
+	return n
+}
+
+//Pop returns a new version without the last item, popping an empty list
+//returns it unchanged
+func (p *PersistentList) Pop() *PersistentList {
+	if p.count <= 1 {
+		return emptyList
+	}
+
+	if p.count-p.tailOffset() > 1 {
+		tail := make([]interface{}, len(p.tail)-1)
+		copy(tail, p.tail)
+		return &PersistentList{p.count - 1, p.shift, p.root, tail}
+	}
+
+	tail := p.leafFor(p.count - 2)
+	root := p.popTail(p.shift, p.root)
+	shift := p.shift
+
+	if root == nil {
+		root = emptyVecNode
+	}
+
+	if shift > vecBits && root.items[1] == nil {
+		root = root.items[0].(*vecNode)
+		shift -= vecBits
+	}
+
+	return &PersistentList{p.count - 1, shift, root, tail}
+}
+
+//popTail copies the path to the last leaf without it, returning nil when the
+//node is left empty
+func (p *PersistentList) popTail(level uint, node *vecNode) *vecNode {
+	sub := ((p.count - 2) >> level) & vecMask
+
+	if level > vecBits {
+		child := p.popTail(level-vecBits, node.items[sub].(*vecNode))
+
+		if child == nil && sub == 0 {
+			return nil
+		}
+
+		n := node.copy()
+
+		if child == nil {
+			n.items[sub] = nil
+		} else {
+			n.items[sub] = child
+		}
+
+		return n
+	}
+
+	if sub == 0 {
+		return nil
+	}
+
+	n := node.copy()
+	n.items[sub] = nil
+	return n
+}
+
+//Add returns a new version with the items appended
+func (p *PersistentList) Add(f ...interface{}) ImmutableListSequencable {
+	n := p
+
+	for _, v := range f {
+		n = n.Conj(v)
+	}
+
+	return n
+}
+
+//Delete returns a new version without the items at the given indexes, each
+//index applies to the list left by the ones before it as with ListSequence.
+//Bad or out of range indexes are skipped
+func (p *PersistentList) Delete(f ...interface{}) ImmutableListSequencable {
+	n := p
+
+	for _, v := range f {
+		i, ok := v.(int)
+
+		if !ok || i < 0 || i >= n.count {
+			continue
+		}
+
+		rest := make([]interface{}, 0, n.count-i-1)
+
+		for j := i + 1; j < n.count; j++ {
+			item, _ := n.Nth(j)
+			rest = append(rest, item)
+		}
+
+		for n.count > i {
+			n = n.Pop()
+		}
+
+		for _, item := range rest {
+			n = n.Conj(item)
+		}
+	}
+
+	return n
+}
+
+//Get retrieves the value at the index, nil if the key is not an int in range
+func (p *PersistentList) Get(d interface{}) interface{} {
+	i, ok := d.(int)
+
+	if !ok {
+		return nil
+	}
+
+	v, _ := p.Nth(i)
+	return v
+}
+
+//Length returns length of data
+func (p *PersistentList) Length() int {
+	return p.count
+}
+
+//Obj returns a copy of the items
+func (p *PersistentList) Obj() []interface{} {
+	items := make([]interface{}, 0, p.count)
+
+	for i := 0; i < p.count; i += vecWidth {
+		leaf := p.leafFor(i)
+		end := vecWidth
+
+		if p.count-i < end {
+			end = p.count - i
+		}
+
+		items = append(items, leaf[:end]...)
+	}
+
+	return items
+}
+
+//Clear returns the empty version
+func (p *PersistentList) Clear() ImmutableListSequencable {
+	return emptyList
+}
+
+//Iterator returns an iterator walking this version
+func (p *PersistentList) Iterator() Iterable {
+	return &PersistentListIterator{p, nil, nil, -1}
+}
+
+//Parent returns the sequence as a sequencable
+func (p *PersistentList) Parent() Sequencable {
+	return Sequencable(p)
+}
+
+//Values returns the items as a ListSequence
+func (p *PersistentList) Values() ListSequencable {
+	return NewListSequence(p.Obj(), 0)
+}
+
+//Keys returns the indexes as a ListSequence
+func (p *PersistentList) Keys() ListSequencable {
+	keys := make([]interface{}, p.count)
+
+	for i := range keys {
+		keys[i] = i
+	}
+
+	return NewListSequence(keys, 0)
+}
+
+//PersistentListIterator walks a PersistentList a leaf at a time
+type PersistentListIterator struct {
+	list  *PersistentList
+	leaf  []interface{}
+	value interface{}
+	index int
+}
+
+//Next moves to the next item
+func (p *PersistentListIterator) Next() error {
+	i := p.index + 1
+
+	if i >= p.list.count {
+		p.value = nil
+		return ErrENDINDEX
+	}
+
+	if p.leaf == nil || i&vecMask == 0 {
+		p.leaf = p.list.leafFor(i)
+	}
+
+	p.index = i
+	p.value = p.leaf[i&vecMask]
+	return nil
+}
+
+//Reset reverst the iterators index
+func (p *PersistentListIterator) Reset() {
+	p.leaf = nil
+	p.value = nil
+	p.index = -1
+}
+
+//Key returns the current index
+func (p *PersistentListIterator) Key() interface{} {
+	if p.index < 0 {
+		return nil
+	}
+	return p.index
+}
+
+//Value returns the current item
+func (p *PersistentListIterator) Value() interface{} {
+	return p.value
+}
+
+//Length returns the size of the list
+func (p *PersistentListIterator) Length() int {
+	return p.list.count
+}
+
+//Clone returns a new iterator off that data
+func (p *PersistentListIterator) Clone() Iterable {
+	return p.list.Iterator()
+}
@@ -0,0 +1,93 @@
+package sequence
+
+import "testing"
+
+func TestPersistentList(t *testing.T) {
+	var v1 ImmutableListSequencable = NewPersistentList([]interface{}{1, 2, 3})
+
+	v2 := v1.Add(4, 5)
+	v3 := v2.Delete(0)
+
+	if !sameValues(v1.Obj(), []interface{}{1, 2, 3}) || !sameValues(v2.Obj(), []interface{}{1, 2, 3, 4, 5}) {
+		t.Fatal("add should leave the old version untouched", v1.Obj(), v2.Obj())
+	}
+
+	if !sameValues(v3.Obj(), []interface{}{2, 3, 4, 5}) || v2.Length() != 5 {
+		t.Fatal("delete should leave the old version untouched", v3.Obj())
+	}
+
+	if v3.Get(0) != 2 || v3.Get(9) != nil || v3.Get("a") != nil {
+		t.Fatal("get is incorrect", v3.Get(0))
+	}
+
+	if res := collect(v3.Iterator()); !sameValues(res, []interface{}{2, 3, 4, 5}) {
+		t.Fatal("iterator is incorrect", res)
+	}
+
+	if v3.Clear().Length() != 0 || v3.Length() != 4 {
+		t.Fatal("clear should return an empty version")
+	}
+
+	if keys := v3.Keys().Obj(); !sameValues(keys, []interface{}{0, 1, 2, 3}) {
+		t.Fatal("keys are incorrect", keys)
+	}
+}
+
+func TestPersistentListTrie(t *testing.T) {
+	const size = 40000
+
+	versions := []*PersistentList{emptyList}
+	p := emptyList
+
+	for i := 0; i < size; i++ {
+		p = p.Conj(i)
+
+		if i%10000 == 0 {
+			versions = append(versions, p)
+		}
+	}
+
+	if p.Length() != size {
+		t.Fatal("conj lost items", p.Length())
+	}
+
+	for i := 0; i < size; i++ {
+		if v, ok := p.Nth(i); !ok || v != i {
+			t.Fatal("nth is incorrect", i, v)
+		}
+	}
+
+	for _, v := range versions[1:] {
+		if last, _ := v.Nth(v.Length() - 1); last != v.Length()-1 {
+			t.Fatal("older versions should keep their items", v.Length(), last)
+		}
+	}
+
+	changed, ok := p.Assoc(1234, "x")
+
+	if !ok || changed.Get(1234) != "x" || p.Get(1234) != 1234 {
+		t.Fatal("assoc should copy the path it changes", changed.Get(1234))
+	}
+
+	if _, ok := p.Assoc(size+1, 0); ok {
+		t.Fatal("assoc past the end should fail")
+	}
+
+	for i := size - 1; i >= 0; i-- {
+		p = p.Pop()
+
+		if p.Length() != i {
+			t.Fatal("pop length is incorrect", i, p.Length())
+		}
+
+		if i > 0 {
+			if v, _ := p.Nth(i - 1); v != i-1 {
+				t.Fatal("pop dropped the wrong item", i, v)
+			}
+		}
+	}
+
+	if res := collect(changed.Iterator()); len(res) != size || res[1234] != "x" || res[size-1] != size-1 {
+		t.Fatal("iterator over a large trie is incorrect", len(res))
+	}
+}
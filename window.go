@@ -0,0 +1,344 @@
+package sequence
+
+import "errors"
+
+//ChunkIterator groups the items of its parent into lists of a fixed size
+type ChunkIterator struct {
+	parent Iterable
+	size   int
+	value  *ListSequence
+	index  int
+	done   bool
+}
+
+//Chunk returns an iterator whoes values are ListSequences of n items from the
+//iterable, keyed by chunk index. The last chunk holds whatever is left
+func Chunk(it Iterable, n int) *ChunkIterator {
+	if n < 1 {
+		n = 1
+	}
+
+	return &ChunkIterator{
+		parent: it.Clone(),
+		size:   n,
+		index:  -1,
+	}
+}
+
+//Next moves to the next chunk
+func (c *ChunkIterator) Next() error {
+	if c.done {
+		return ErrENDINDEX
+	}
+
+	items := make([]interface{}, 0, c.size)
+
+	for len(items) < c.size {
+		err := c.parent.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			c.done = true
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		items = append(items, c.parent.Value())
+	}
+
+	if len(items) == 0 {
+		c.value = nil
+		return ErrENDINDEX
+	}
+
+	c.value = NewListSequence(items, 0)
+	c.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (c *ChunkIterator) Reset() {
+	c.parent.Reset()
+	c.value = nil
+	c.index = -1
+	c.done = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (c *ChunkIterator) Stop() {
+	stop(c.parent)
+}
+
+//Key returns the current chunk index
+func (c *ChunkIterator) Key() interface{} {
+	if c.index < 0 {
+		return nil
+	}
+	return c.index
+}
+
+//Value returns the current chunk as a ListSequence
+func (c *ChunkIterator) Value() interface{} {
+	if c.value == nil {
+		return nil
+	}
+	return c.value
+}
+
+//Length returns the number of chunks or UNKNOWNLENGTH if the parent can not
+//tell its length
+func (c *ChunkIterator) Length() int {
+	n, ok := sizeOf(c.parent)
+
+	if !ok {
+		return UNKNOWNLENGTH
+	}
+
+	return (n + c.size - 1) / c.size
+}
+
+//Clone returns a new iterator off that data
+func (c *ChunkIterator) Clone() Iterable {
+	return Chunk(c.parent, c.size)
+}
+
+//WindowIterator slides a fixed size window over its parent
+type WindowIterator struct {
+	parent Iterable
+	size   int
+	step   int
+	buf    []interface{}
+	value  *ListSequence
+	index  int
+	done   bool
+}
+
+//Window returns an iterator whoes values are ListSequences of size items,
+//each window starting step items after the one before it, keyed by window
+//index. Trailing items that can not fill a window are dropped
+func Window(it Iterable, size, step int) *WindowIterator {
+	if size < 1 {
+		size = 1
+	}
+
+	if step < 1 {
+		step = 1
+	}
+
+	return &WindowIterator{
+		parent: it.Clone(),
+		size:   size,
+		step:   step,
+		index:  -1,
+	}
+}
+
+//Next moves to the next window
+func (w *WindowIterator) Next() error {
+	if w.done {
+		return ErrENDINDEX
+	}
+
+	skip := 0
+
+	if w.index >= 0 {
+		if w.step < len(w.buf) {
+			w.buf = append(w.buf[:0], w.buf[w.step:]...)
+		} else {
+			skip = w.step - len(w.buf)
+			w.buf = w.buf[:0]
+		}
+	}
+
+	for skip > 0 || len(w.buf) < w.size {
+		err := w.parent.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			w.done = true
+			w.value = nil
+			return ErrENDINDEX
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if skip > 0 {
+			skip--
+			continue
+		}
+
+		w.buf = append(w.buf, w.parent.Value())
+	}
+
+	items := make([]interface{}, w.size)
+	copy(items, w.buf)
+
+	w.value = NewListSequence(items, 0)
+	w.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (w *WindowIterator) Reset() {
+	w.parent.Reset()
+	w.buf = w.buf[:0]
+	w.value = nil
+	w.index = -1
+	w.done = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (w *WindowIterator) Stop() {
+	stop(w.parent)
+}
+
+//Key returns the current window index
+func (w *WindowIterator) Key() interface{} {
+	if w.index < 0 {
+		return nil
+	}
+	return w.index
+}
+
+//Value returns the current window as a ListSequence
+func (w *WindowIterator) Value() interface{} {
+	if w.value == nil {
+		return nil
+	}
+	return w.value
+}
+
+//Length returns the number of windows or UNKNOWNLENGTH if the parent can not
+//tell its length
+func (w *WindowIterator) Length() int {
+	n, ok := sizeOf(w.parent)
+
+	if !ok {
+		return UNKNOWNLENGTH
+	}
+
+	if n < w.size {
+		return 0
+	}
+
+	return (n-w.size)/w.step + 1
+}
+
+//Clone returns a new iterator off that data
+func (w *WindowIterator) Clone() Iterable {
+	return Window(w.parent, w.size, w.step)
+}
+
+//SplitIterator groups the items of its parent into lists, starting a new
+//list at every item its predicate accepts
+type SplitIterator struct {
+	parent Iterable
+	pred   PredFunc
+	held   interface{}
+	hold   bool
+	value  *ListSequence
+	index  int
+	done   bool
+}
+
+//SplitWhen returns an iterator whoes values are ListSequences of consecutive
+//items, a new list starts at each item, other than the very first, that
+//passes the predicate. Keys are the list index
+func SplitWhen(it Iterable, fn PredFunc) *SplitIterator {
+	return &SplitIterator{
+		parent: it.Clone(),
+		pred:   fn,
+		index:  -1,
+	}
+}
+
+//Next moves to the next list
+func (s *SplitIterator) Next() error {
+	if s.done && !s.hold {
+		s.value = nil
+		return ErrENDINDEX
+	}
+
+	var items []interface{}
+
+	if s.hold {
+		items = append(items, s.held)
+		s.held = nil
+		s.hold = false
+	}
+
+	for !s.done {
+		err := s.parent.Next()
+
+		if errors.Is(err, ErrENDINDEX) {
+			s.done = true
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(items) > 0 && s.pred(s.parent) {
+			s.held = s.parent.Value()
+			s.hold = true
+			break
+		}
+
+		items = append(items, s.parent.Value())
+	}
+
+	if len(items) == 0 {
+		s.value = nil
+		return ErrENDINDEX
+	}
+
+	s.value = NewListSequence(items, 0)
+	s.index++
+	return nil
+}
+
+//Reset reverst the iterators index
+func (s *SplitIterator) Reset() {
+	s.parent.Reset()
+	s.held = nil
+	s.hold = false
+	s.value = nil
+	s.index = -1
+	s.done = false
+}
+
+//Stop releases the parent iterator if it holds resources
+func (s *SplitIterator) Stop() {
+	stop(s.parent)
+}
+
+//Key returns the current list index
+func (s *SplitIterator) Key() interface{} {
+	if s.index < 0 {
+		return nil
+	}
+	return s.index
+}
+
+//Value returns the current list as a ListSequence
+func (s *SplitIterator) Value() interface{} {
+	if s.value == nil {
+		return nil
+	}
+	return s.value
+}
+
+//Length returns UNKNOWNLENGTH as the split points are only known after
+//iteration
+func (s *SplitIterator) Length() int {
+	return UNKNOWNLENGTH
+}
+
+//Clone returns a new iterator off that data
+func (s *SplitIterator) Clone() Iterable {
+	return SplitWhen(s.parent, s.pred)
+}
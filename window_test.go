@@ -0,0 +1,110 @@
+package sequence
+
+import "testing"
+
+func lists(it Iterable) [][]interface{} {
+	var res [][]interface{}
+
+	for it.Next() == nil {
+		ls, _ := it.Value().(*ListSequence)
+		res = append(res, ls.Obj())
+	}
+
+	return res
+}
+
+func sameLists(a, b [][]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !sameValues(a[i], b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestChunk(t *testing.T) {
+	items := []interface{}{1, 2, 3, 4, 5}
+	c := Chunk(NewListIterator(items), 2)
+
+	if c.Length() != 3 {
+		t.Fatal("chunk count is incorrect", c.Length())
+	}
+
+	res := lists(c)
+
+	if !sameLists(res, [][]interface{}{{1, 2}, {3, 4}, {5}}) {
+		t.Fatal("chunks are incorrect", res)
+	}
+
+	if c.Key() != 2 {
+		t.Fatal("chunk keys should be chunk indexes", c.Key())
+	}
+
+	res = lists(Take(Chunk(counter(), 3), 2))
+
+	if !sameLists(res, [][]interface{}{{0, 1, 2}, {3, 4, 5}}) {
+		t.Fatal("chunks over a generator are incorrect", res)
+	}
+}
+
+func TestWindow(t *testing.T) {
+	items := []interface{}{1, 2, 3, 4, 5}
+	w := Window(NewListIterator(items), 3, 1)
+
+	if w.Length() != 3 {
+		t.Fatal("window count is incorrect", w.Length())
+	}
+
+	res := lists(w)
+
+	if !sameLists(res, [][]interface{}{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}) {
+		t.Fatal("sliding windows are incorrect", res)
+	}
+
+	w = Window(NewListIterator(items), 2, 3)
+
+	if w.Length() != 2 {
+		t.Fatal("stepped window count is incorrect", w.Length())
+	}
+
+	if res = lists(w); !sameLists(res, [][]interface{}{{1, 2}, {4, 5}}) {
+		t.Fatal("stepped windows are incorrect", res)
+	}
+
+	w.Reset()
+
+	if res = lists(w); len(res) != 2 {
+		t.Fatal("reset window should start over", res)
+	}
+
+	res = lists(Take(Window(counter(), 2, 2), 2))
+
+	if !sameLists(res, [][]interface{}{{0, 1}, {2, 3}}) {
+		t.Fatal("windows over a generator are incorrect", res)
+	}
+}
+
+func TestSplitWhen(t *testing.T) {
+	items := []interface{}{2, 1, 3, 4, 5, 6}
+	s := SplitWhen(NewListIterator(items), even)
+
+	res := lists(s)
+
+	if !sameLists(res, [][]interface{}{{2, 1, 3}, {4, 5}, {6}}) {
+		t.Fatal("split lists are incorrect", res)
+	}
+
+	res = lists(Take(SplitWhen(counter(), func(f Iterable) bool {
+		v, _ := f.Value().(int)
+		return v%3 == 0
+	}), 2))
+
+	if !sameLists(res, [][]interface{}{{0, 1, 2}, {3, 4, 5}}) {
+		t.Fatal("split lists over a generator are incorrect", res)
+	}
+}
@@ -0,0 +1,232 @@
+package sequence
+
+import "sync"
+
+//SeqWriter queues writes on a buffered channel and applies them in batches,
+//taking the sequence lock once per batch instead of once per write
+type SeqWriter struct {
+	ops     chan func()
+	lock    *sync.RWMutex
+	unlock  func()
+	after   []func()
+	mu      sync.Mutex
+	running bool
+}
+
+//NewSeqWriter returns a writer with a queue of the given size applying its
+//writes under the supplied lock
+func NewSeqWriter(buff int, lock *sync.RWMutex) *SeqWriter {
+	if buff < MINBUFF {
+		buff = MINBUFF
+	}
+
+	return &SeqWriter{
+		ops:    make(chan func(), buff),
+		lock:   lock,
+		unlock: lock.Unlock,
+	}
+}
+
+//Stack queues a write, blocking only while the queue is full. The write runs
+//with the lock held and must not take it itself, if it panics it is dropped
+func (w *SeqWriter) Stack(fn func()) {
+	w.ops <- fn
+
+	w.mu.Lock()
+	if !w.running {
+		w.running = true
+		go w.run()
+	}
+	w.mu.Unlock()
+}
+
+//Flush blocks until every write stacked before it has been applied and the
+//lock they ran under released
+func (w *SeqWriter) Flush() {
+	done := make(chan struct{})
+
+	w.Stack(func() {
+		w.after = append(w.after, func() {
+			close(done)
+		})
+	})
+
+	<-done
+}
+
+//apply runs a batch of writes under the lock and returns the hooks to run
+//once it is released. A write that panics is dropped, so it can neither take
+//the process down from the writer goroutine nor keep the lock
+func (w *SeqWriter) apply(batch []func()) []func() {
+	w.lock.Lock()
+	defer w.unlock()
+
+	for _, op := range batch {
+		attempt(op)
+	}
+
+	after := w.after
+	w.after = nil
+	return after
+}
+
+//attempt runs the write, recovering from any panic in it
+func attempt(op func()) {
+	defer func() {
+		recover()
+	}()
+
+	op()
+}
+
+//run applies queued writes until the queue is empty, at which point the
+//goroutine exits and the next Stack starts another
+func (w *SeqWriter) run() {
+	for {
+		select {
+		case op := <-w.ops:
+			batch := []func(){op}
+
+		drain:
+			for len(batch) < cap(w.ops) {
+				select {
+				case op := <-w.ops:
+					batch = append(batch, op)
+				default:
+					break drain
+				}
+			}
+
+			for _, fn := range w.apply(batch) {
+				fn()
+			}
+		default:
+			w.mu.Lock()
+			if len(w.ops) == 0 {
+				w.running = false
+				w.mu.Unlock()
+				return
+			}
+			w.mu.Unlock()
+		}
+	}
+}
+
+//add appends the items, the caller must hold the lock
+func (l *ListSequence) add(f ...interface{}) {
+	l.own()
+
+	for i, v := range f {
+		l.record(Change{ChangeAdd, len(l.data) + i, nil, v})
+	}
+
+	l.data = append(l.data, f...)
+}
+
+//del removes the items at each index in turn, the caller must hold the lock
+func (l *ListSequence) del(f ...interface{}) {
+	for _, v := range f {
+		i, ok := v.(int)
+
+		if !ok || i < 0 || i >= len(l.data) {
+			continue
+		}
+
+		l.own()
+		l.record(Change{ChangeDelete, i, l.data[i], nil})
+		copy(l.data[i:], l.data[i+1:])
+		l.data[len(l.data)-1] = nil
+		l.data = l.data[:len(l.data)-1]
+	}
+}
+
+//mutate replaces the data with the result of fn if it is still a list, the
+//caller must hold the lock
+func (l *ListSequence) mutate(fn MutFunc) {
+	old := l.before()
+	l.own()
+
+	if res, ok := fn(l.data).([]interface{}); ok {
+		l.data = res
+		l.mutated(old)
+	}
+}
+
+//QueueAdd stacks an Add on the sequence writer
+func (l *ListSequence) QueueAdd(f ...interface{}) {
+	l.queue().Stack(func() {
+		l.add(f...)
+	})
+}
+
+//QueueDelete stacks a Delete on the sequence writer, indexes that are not in
+//range when the write is applied are skipped
+func (l *ListSequence) QueueDelete(f ...interface{}) {
+	l.queue().Stack(func() {
+		l.del(f...)
+	})
+}
+
+//QueueMutate stacks a Mutate on the sequence writer
+func (l *ListSequence) QueueMutate(fn MutFunc) {
+	l.queue().Stack(func() {
+		l.mutate(fn)
+	})
+}
+
+//add sets each key and value pair, an odd trailing key is ignored. The caller
+//must hold the lock
+func (l *MapSequence) add(f ...interface{}) {
+	for i := 0; i+1 < len(f); i += 2 {
+		l.set(f[i], f[i+1])
+	}
+}
+
+//del removes the keys, the caller must hold the lock
+func (l *MapSequence) del(f ...interface{}) {
+	for _, k := range f {
+		l.remove(k)
+	}
+}
+
+//mutate replaces the data with the result of fn if it is still a map, the
+//caller must hold the lock
+func (l *MapSequence) mutate(fn MutFunc) {
+	old := l.before()
+	l.own()
+
+	if res, ok := fn(l.data).(map[interface{}]interface{}); ok {
+		l.data = res
+		l.syncKeys()
+		l.mutated(old)
+	}
+}
+
+//QueueAdd stacks an Add of key and value pairs on the sequence writer, it
+//panics with ErrKeyType right away, as Add would, if a key can not be a map
+//key
+func (l *MapSequence) QueueAdd(f ...interface{}) {
+	for i := 0; i+1 < len(f); i += 2 {
+		if !keyable(f[i]) {
+			panic(ErrKeyType)
+		}
+	}
+
+	l.queue().Stack(func() {
+		l.add(f...)
+	})
+}
+
+//QueueDelete stacks a Delete on the sequence writer
+func (l *MapSequence) QueueDelete(f ...interface{}) {
+	l.queue().Stack(func() {
+		l.del(f...)
+	})
+}
+
+//QueueMutate stacks a Mutate on the sequence writer
+func (l *MapSequence) QueueMutate(fn MutFunc) {
+	l.queue().Stack(func() {
+		l.mutate(fn)
+	})
+}
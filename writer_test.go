@@ -0,0 +1,94 @@
+package sequence
+
+import "sync"
+import "testing"
+
+func TestListQueueWrites(t *testing.T) {
+	ls := NewListSequence(nil, 5)
+
+	if ls.writer != nil {
+		t.Fatal("the writer should only be made once a write is queued")
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				ls.QueueAdd(i)
+			}
+		}()
+	}
+
+	wg.Wait()
+	ls.Flush()
+
+	if ls.Length() != 400 {
+		t.Fatal("every queued add should be applied after Flush", ls.Length())
+	}
+
+	ls.QueueMutate(func(f interface{}) interface{} {
+		return f.([]interface{})[:3]
+	})
+	ls.QueueDelete(0, 10)
+	ls.QueueAdd("last")
+	ls.Flush()
+
+	if ls.Length() != 3 || ls.Get(2) != "last" {
+		t.Fatal("queued writes should apply in order", ls.Length(), ls.Obj())
+	}
+}
+
+func TestMapQueueWrites(t *testing.T) {
+	ms := NewMapSequence(nil, 0)
+
+	func() {
+		defer func() {
+			if r := recover(); r != ErrKeyType {
+				t.Fatal("queueing an unhashable key should panic with ErrKeyType in the caller", r)
+			}
+		}()
+		ms.QueueAdd([]int{1}, 2)
+	}()
+
+	ms.QueueAdd(1, "a", 2, "b", 3)
+	ms.QueueAdd(3, "c")
+	ms.QueueDelete(2)
+	ms.Flush()
+
+	if ms.Length() != 2 || ms.Get(1) != "a" || ms.Get(3) != "c" {
+		t.Fatal("queued map writes are incorrect", ms.Obj())
+	}
+
+	ms.QueueMutate(func(f interface{}) interface{} {
+		return map[interface{}]interface{}{"k": "v"}
+	})
+	ms.Flush()
+
+	if ms.Length() != 1 || ms.Get("k") != "v" {
+		t.Fatal("queued map mutate is incorrect", ms.Obj())
+	}
+
+	ms.Flush()
+}
+
+func TestQueuedPanicKeepsWriting(t *testing.T) {
+	ls := NewListSequence([]interface{}{1, 2}, 0)
+
+	ls.QueueMutate(func(f interface{}) interface{} {
+		panic("bad mutate")
+	})
+	ls.QueueAdd(3)
+	ls.Flush()
+
+	if !sameValues(ls.Obj(), []interface{}{1, 2, 3}) {
+		t.Fatal("a panicking queued write should be dropped and the rest applied", ls.Obj())
+	}
+
+	ls.Add(4)
+
+	if ls.Length() != 4 {
+		t.Fatal("the lock should be free after a panicking queued write", ls.Obj())
+	}
+}